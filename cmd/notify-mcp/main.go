@@ -9,6 +9,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/zboyco/notify-mcp/internal/config"
 	"github.com/zboyco/notify-mcp/internal/mcp"
@@ -55,16 +56,14 @@ func runConfig(args []string) error {
 	fs.SetOutput(io.Discard)
 
 	var (
-		method                string
-		apiURL, chatID, token string
-		remove                bool
-		showHelp              bool
-		messageFlag           stringFlag
+		method      string
+		opts        methodOptions
+		remove      bool
+		showHelp    bool
+		messageFlag stringFlag
 	)
-	fs.StringVar(&method, "method", "", "要配置的通知方式，例如 telegram 或 os")
-	fs.StringVar(&apiURL, "api-url", "", "Telegram API基础地址，默认为 https://api.telegram.org")
-	fs.StringVar(&chatID, "chat-id", "", "Telegram Chat ID")
-	fs.StringVar(&token, "token", "", "Telegram Bot Token")
+	fs.StringVar(&method, "method", "", "要配置的通知方式，例如 telegram、slack 或 os")
+	registerMethodFlags(fs, &opts)
 	fs.Var(&messageFlag, "message", "通知内容，默认为 '即将进行汇报，请注意查看...'")
 	fs.BoolVar(&remove, "remove", false, "移除指定的通知方式")
 	fs.BoolVar(&showHelp, "h", false, "显示帮助信息")
@@ -87,7 +86,8 @@ func runConfig(args []string) error {
 		return nil
 	}
 
-	methodChangeRequested := method != "" || apiURL != "" || chatID != "" || token != "" || remove
+	setFlags := methodFlagsSet(fs)
+	methodChangeRequested := method != "" || len(setFlags) > 0 || remove
 	updateRequested := methodChangeRequested || messageFlag.isSet
 	if !updateRequested {
 		return showCurrentConfig()
@@ -108,8 +108,8 @@ func runConfig(args []string) error {
 
 	if methodChangeRequested {
 		if remove {
-			if apiURL != "" || chatID != "" || token != "" {
-				return errors.New("移除通知方式时无需提供 --api-url/--chat-id/--token 参数")
+			if len(setFlags) > 0 {
+				return fmt.Errorf("移除通知方式时无需提供 --%s 参数", strings.Join(setFlags, "/--"))
 			}
 			var removed bool
 			settings.Methods, removed = removeMethod(settings.Methods, config.MethodType(method))
@@ -117,36 +117,11 @@ func runConfig(args []string) error {
 				return fmt.Errorf("通知方式 %s 尚未配置", method)
 			}
 		} else {
-			switch config.MethodType(method) {
-			case config.MethodTelegram:
-				if chatID == "" || token == "" {
-					return errors.New("更新 Telegram 配置时必须提供 --chat-id, --token，可选 --api-url")
-				}
-				if apiURL == "" {
-					apiURL = config.DefaultTelegramAPIBaseURL
-				}
-				tgCfg := config.TelegramConfig{
-					APIBaseURL: apiURL,
-					ChatID:     chatID,
-					Token:      token,
-				}
-				method, err := config.NewTelegramMethod(tgCfg)
-				if err != nil {
-					return err
-				}
-				settings.Methods = upsertMethod(settings.Methods, method)
-			case config.MethodOS:
-				if apiURL != "" || chatID != "" || token != "" {
-					return errors.New("操作系统通知无需 --api-url/--chat-id/--token 参数")
-				}
-				method, err := config.NewOSMethod()
-				if err != nil {
-					return err
-				}
-				settings.Methods = upsertMethod(settings.Methods, method)
-			default:
-				return fmt.Errorf("不支持的通知方式: %s", method)
+			method, err := buildMethod(config.MethodType(method), opts, setFlags)
+			if err != nil {
+				return err
 			}
+			settings.Methods = upsertMethod(settings.Methods, method)
 		}
 	}
 
@@ -155,7 +130,7 @@ func runConfig(args []string) error {
 	}
 
 	if len(settings.Methods) == 0 {
-		return errors.New("请至少指定一种通知方式（例如 Telegram 或 os）")
+		return errors.New("请至少指定一种通知方式（例如 Telegram、Slack 或 os）")
 	}
 
 	if err := config.Save(settings); err != nil {
@@ -221,15 +196,216 @@ func printConfigUsage(program string) {
       显示当前配置内容。
 
   %s config --method <method> [其它参数]
-      根据通知方式更新或移除配置。method 取值：telegram, os
+      根据通知方式更新或移除配置。method 取值：
+        telegram, os, slack, discord, wecom, dingtalk, feishu, bark, ntfy,
+        gotify, pushover, email, webhook, teams, matrix, mattermost, rocketchat,
+        googlechat, serverchan, pushplus, terminal, exec, log, syslog, mqtt,
+        homeassistant, pagerduty, opsgenie, twilio, signal
 
 参数说明:
-  --method    要配置的通知方式（telegram / os）
-  --remove    移除指定通知方式
-  --api-url   Telegram API基础地址，默认为 https://api.telegram.org
-  --chat-id   Telegram Chat ID
-  --token     Telegram Bot Token
-  --message   通知内容文案
+  --method       要配置的通知方式（取值见上方）
+  --remove       移除指定通知方式
+  --message      通知内容文案
+
+Telegram:
+  --api-url      Telegram API基础地址，默认为 https://api.telegram.org
+  --chat-id      Telegram Chat ID
+  --token        Telegram Bot Token
+
+Slack:
+  --webhook-url  Slack Incoming Webhook 地址
+  --channel      频道覆盖（可选），例如 #alerts
+  --username     发送者名称（可选）
+  --icon-emoji   发送者头像 emoji（可选），例如 :robot_face:
+
+Discord:
+  --webhook-url  Discord Webhook 地址
+  --username     发送者名称（可选）
+  --avatar-url   发送者头像图片地址（可选）
+
+企业微信 (wecom):
+  --key               群机器人 Webhook 地址中的 key
+  --msg-type          消息类型 markdown / text，默认为 markdown
+  --mentioned         提醒的成员 userid，逗号分隔，@all 表示所有人，仅 text 类型（可选）
+  --mentioned-mobile  提醒的成员手机号，逗号分隔，仅 text 类型（可选）
+  --api-url           接口基础地址，默认为 https://qyapi.weixin.qq.com
+
+钉钉 (dingtalk):
+  --token       机器人 Webhook 地址中的 access_token
+  --secret      加签密钥（可选，安全设置启用「加签」时必填）
+  --keyword     自定义关键词（可选，缺失时自动附加到消息中）
+  --at-mobiles  需要 @ 的成员手机号，逗号分隔（可选）
+  --at-all      @ 所有人（可选）
+  --api-url     接口基础地址，默认为 https://oapi.dingtalk.com
+
+飞书 / Lark (feishu):
+  --webhook-url  自定义机器人 Webhook 地址
+  --secret       签名校验密钥（可选，安全设置启用「签名校验」时必填）
+  --link-url     卡片「查看详情」按钮的跳转地址（可选）
+
+Bark (bark):
+  --device-keys         设备 key，多个以逗号分隔
+  --server-url          服务地址，默认为 https://api.day.app
+  --group               推送分组（可选）
+  --sound               推送铃声（可选）
+  --interruption-level  中断级别 active / timeSensitive / passive（可选）
+  --icon-url            推送图标地址（可选）
+  --click-url           点击推送后跳转的地址（可选）
+  --encrypt-key         AES 密钥，16/24/32 位，启用加密推送时必填
+  --encrypt-iv          AES IV，16 位，启用加密推送时必填
+
+ntfy (ntfy):
+  --topic       发布的主题
+  --server-url  服务地址，默认为 https://ntfy.sh
+  --username    Basic 认证用户名（可选）
+  --password    Basic 认证密码（可选）
+  --token       Bearer 访问令牌（可选，与用户名密码二选一）
+  --priority    消息优先级 1-5（可选）
+  --tags        消息标签，逗号分隔（可选）
+  --click-url   点击通知后跳转的地址（可选）
+  --actions     操作按钮，格式为 名称=地址，多个以分号分隔（可选）
+
+Gotify (gotify):
+  --server-url  Gotify 服务地址
+  --token       应用令牌（App Token）
+  --priority    消息优先级 0-10，0 为静默，未指定时使用应用默认优先级（可选）
+
+Pushover (pushover):
+  --token     应用令牌（API Token）
+  --user-key  用户或群组 key
+  --device    目标设备名称（可选）
+  --sound     提示音（可选）
+  --priority  优先级 -2 到 2，2 为紧急通知，需用户确认（可选）
+  --retry     紧急通知重复提醒间隔秒数，至少 30，默认为 60（可选）
+  --expire    紧急通知持续提醒秒数，最多 10800，默认为 3600（可选）
+  --api-url   接口基础地址，默认为 https://api.pushover.net
+
+邮件 (email):
+  --smtp-host      SMTP 服务器地址
+  --smtp-port      SMTP 端口，默认根据加密方式选择 587 / 465 / 25
+  --smtp-security  加密方式 starttls / tls / none，默认为 starttls
+  --username       SMTP 认证用户名（可选）
+  --password       SMTP 认证密码（可选）
+  --from           发件人，例如 "Notify <bot@example.com>"
+  --to             收件人，多个以逗号分隔
+  --cc             抄送人，多个以逗号分隔（可选）
+
+通用 Webhook (webhook):
+  --webhook-url       请求地址
+  --http-method       HTTP 方法，默认为 POST（可选）
+  --header            请求头，格式为 "Key: Value"，可重复指定（可选）
+  --body-template     请求体模板，Go text/template 语法，可引用 .TaskName .Message
+                      .Timestamp .Time .Host .Level .Text，json 函数输出 JSON 字符串，GET 请求不支持（可选）
+  --success-codes     视为成功的状态码，逗号分隔，默认为任意 2xx（可选）
+  --secret            HMAC-SHA256 签名密钥（可选）
+  --signature-header  签名请求头，默认为 X-Notify-Signature（可选）
+
+Microsoft Teams (teams):
+  --webhook-url  Incoming Webhook 或 Workflows（Power Automate）触发地址
+  --link-url     卡片 "Open" 按钮的跳转地址（可选）
+
+Matrix (matrix):
+  --server-url  Homeserver 地址，例如 https://matrix.org
+  --token       访问令牌（Access Token）
+  --room-id     房间 ID，例如 !abc:example.org
+
+Mattermost (mattermost) / Rocket.Chat (rocketchat):
+  --webhook-url  Incoming Webhook 地址
+  --channel      频道覆盖（可选）
+  --username     发送者名称（可选）
+  --icon-url     发送者头像地址（可选）
+  --icon-emoji   发送者头像 emoji（可选）
+
+Google Chat (googlechat):
+  --webhook-url  空间 Webhook 地址（包含 key 与 token 参数）
+
+Server酱 (serverchan):
+  --key      SendKey，支持 Turbo 版（SCT 开头）与 Server酱³（sctp 开头）
+  --api-url  接口基础地址（可选），默认根据 SendKey 自动选择
+
+PushPlus (pushplus):
+  --token     用户 token
+  --topic     群组编码，一对多推送时填写（可选）
+  --msg-type  消息模板 html / markdown，默认为 html
+  --api-url   接口基础地址，默认为 https://www.pushplus.plus
+
+终端转义序列 (terminal):
+  --protocol     通知协议，默认为 osc9（可选）
+                 osc9: iTerm2 / WezTerm / Windows Terminal
+                 osc777: urxvt / foot / Ghostty
+                 osc99: kitty
+  --passthrough  tmux / screen 透传方式 auto / tmux / screen / none，默认为 auto（可选）
+  --tty          写入的终端设备，默认为当前控制终端（可选）
+
+命令执行 (exec):
+  --command  要执行的命令
+  --arg      命令参数，可重复指定（可选）
+  --timeout  超时时间（秒），默认为 30（可选）
+             通知内容通过 NOTIFY_TASK / NOTIFY_MESSAGE / NOTIFY_LEVEL /
+             NOTIFY_TIMESTAMP 环境变量及 stdin 上的 JSON 传递给命令
+
+日志文件 (log):
+  --path         JSONL 日志文件路径
+  --max-size     单个文件轮转大小（MB），默认为 10，0 表示不轮转（可选）
+  --max-backups  保留的历史文件数量，开启轮转时至少为 1，默认为 3（可选）
+
+系统日志 (syslog):
+  --backend  写入后端 auto / syslog / journald，默认为 auto（可选）
+  --ident    日志标识，默认为 notify-mcp（可选）
+
+MQTT (mqtt):
+  --server-url  Broker 地址，例如 mqtt://localhost:1883，mqtts:// 使用 TLS
+  --username    认证用户名（可选）
+  --password    认证密码（可选）
+  --client-id   客户端 ID，默认随机生成（可选）
+  --topic       发布主题模板，可引用 .TaskName .Level .Host，
+                默认为 notify-mcp/{{.Level}}（可选）
+  --qos         QoS 0 / 1，默认为 0（可选）
+  --retain      设置 retain 标志（可选）
+  --ca-file     TLS 连接使用的 CA 证书文件（可选）
+
+Home Assistant (homeassistant):
+  --server-url     Home Assistant 地址，例如 http://homeassistant.local:8123
+  --token          长期访问令牌
+  --service        notify 服务名，例如 mobile_app_pixel_8
+  --push-priority  推送优先级 normal / high（可选）
+  --tag            通知 tag，相同 tag 的通知会相互替换（可选）
+  --actions        操作按钮，格式为 名称=地址，多个以分号分隔（可选）
+
+PagerDuty (pagerduty):
+  --key         Events API v2 集成的 Routing Key
+  --severities  严重级别映射，格式为 级别=严重级别，逗号分隔，例如 error=critical，
+                默认 info/warning/error/critical 映射为同名严重级别（可选）
+  --min-level   触发事件的最低通知级别 info / warning / error / critical，默认为 error（可选）
+  --api-url     接口基础地址，默认为 https://events.pagerduty.com
+  通知级别为 success 时会恢复（resolve）同一任务的事件
+
+Opsgenie (opsgenie):
+  --key         API Key（API 集成密钥）
+  --region      账号区域 us / eu，默认为 us（可选）
+  --responders  响应者，格式为 类型:名称，类型可选 team / user / escalation / schedule，
+                逗号分隔（可选）
+  --priority    告警优先级 1-5，对应 P1-P5（可选）
+  --min-level   创建告警的最低通知级别 info / warning / error / critical，默认为 error（可选）
+  --api-url     接口基础地址，设置后忽略 --region（可选）
+  通知级别为 success 时会关闭同一任务的告警
+
+Twilio (twilio):
+  --account-sid     Account SID
+  --token           Auth Token
+  --from            发送号码（需为 Twilio 号码）
+  --to              接收号码，E.164 格式，多个以逗号分隔
+  --voice           critical 级别通知时额外拨打语音电话（可选）
+  --voice-language  语音播报语言，例如 cmn-CN（可选）
+  --api-url         接口基础地址，默认为 https://api.twilio.com（可选）
+  短信内容会截断为 140 字以内；失败的号码会在通知结果中列出
+
+Signal (signal):
+  --server-url  signal-cli-rest-api 地址，例如 http://localhost:8080
+  --from        已在 signal-cli 中注册的发送号码，E.164 格式
+  --to          接收号码，多个以逗号分隔
+  --group-ids   群组 ID（GET /v1/groups/{number} 返回的 id），逗号分隔
+  --to 与 --group-ids 至少提供一项；失败的收件人会在通知结果中列出
 `, name, name)
 }
 
@@ -0,0 +1,1024 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+)
+
+// methodOptions 汇总 config 子命令中各通知方式专属的参数。
+type methodOptions struct {
+	apiURL, chatID, token string
+
+	webhookURL string
+	channel    string
+	username   string
+	iconEmoji  string
+	avatarURL  string
+
+	key             string
+	msgType         string
+	mentioned       string
+	mentionedMobile string
+
+	secret    string
+	keyword   string
+	atMobiles string
+	atAll     bool
+
+	linkURL string
+
+	serverURL         string
+	deviceKeys        string
+	group             string
+	sound             string
+	interruptionLevel string
+	iconURL           string
+	clickURL          string
+	encryptKey        string
+	encryptIV         string
+
+	topic    string
+	password string
+	priority int
+	tags     string
+	actions  string
+
+	userKey string
+	device  string
+	retry   int
+	expire  int
+
+	smtpHost     string
+	smtpPort     int
+	smtpSecurity string
+	from         string
+	to           string
+	cc           string
+
+	httpMethod      string
+	headers         stringsFlag
+	bodyTemplate    string
+	successCodes    string
+	signatureHeader string
+
+	roomID string
+
+	protocol    string
+	passthrough string
+	tty         string
+
+	command string
+	args    stringsFlag
+	timeout int
+
+	path       string
+	maxSize    int
+	maxBackups int
+	backend    string
+	ident      string
+
+	clientID string
+	qos      int
+	retain   bool
+	caFile   string
+
+	service      string
+	pushPriority string
+	tag          string
+
+	severities string
+	minLevel   string
+	region     string
+	responders string
+
+	accountSID    string
+	voice         bool
+	voiceLanguage string
+
+	groupIDs string
+}
+
+// stringsFlag 收集可重复指定的参数值。
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(val string) error {
+	*f = append(*f, val)
+	return nil
+}
+
+// commonFlags 是与具体通知方式无关的参数。
+var commonFlags = map[string]bool{
+	"method":  true,
+	"remove":  true,
+	"message": true,
+	"h":       true,
+	"help":    true,
+}
+
+func registerMethodFlags(fs *flag.FlagSet, opts *methodOptions) {
+	fs.StringVar(&opts.apiURL, "api-url", "", "Telegram / 企业微信 / 钉钉 / Pushover / Server酱 / PushPlus / PagerDuty / Opsgenie / Twilio API基础地址，默认使用官方地址")
+	fs.StringVar(&opts.chatID, "chat-id", "", "Telegram Chat ID")
+	fs.StringVar(&opts.token, "token", "", "Telegram Bot Token / 钉钉机器人 access_token / ntfy 访问令牌 / Gotify 或 Pushover 应用令牌 / Matrix 访问令牌 / PushPlus 用户 token / Home Assistant 长期访问令牌 / Twilio Auth Token")
+	fs.StringVar(&opts.webhookURL, "webhook-url", "", "Slack / Discord / 飞书 / Teams / Mattermost / Rocket.Chat / Google Chat / 通用 Webhook 地址")
+	fs.StringVar(&opts.channel, "channel", "", "Slack / Mattermost / Rocket.Chat 频道覆盖，例如 #alerts")
+	fs.StringVar(&opts.username, "username", "", "Slack / Discord / Mattermost / Rocket.Chat 消息显示的发送者名称，或 ntfy / SMTP / MQTT 认证用户名")
+	fs.StringVar(&opts.iconEmoji, "icon-emoji", "", "Slack / Mattermost / Rocket.Chat 消息显示的头像 emoji，例如 :robot_face:")
+	fs.StringVar(&opts.avatarURL, "avatar-url", "", "Discord 消息显示的头像图片地址")
+	fs.StringVar(&opts.key, "key", "", "企业微信群机器人 Webhook key / Server酱 SendKey / PagerDuty Routing Key / Opsgenie API Key")
+	fs.StringVar(&opts.msgType, "msg-type", "", "企业微信消息类型（markdown / text）或 PushPlus 模板（html / markdown），默认为 markdown / html")
+	fs.StringVar(&opts.mentioned, "mentioned", "", "企业微信需要提醒的成员 userid，多个以逗号分隔，@all 表示所有人（仅 text 类型）")
+	fs.StringVar(&opts.mentionedMobile, "mentioned-mobile", "", "企业微信需要提醒的成员手机号，多个以逗号分隔（仅 text 类型）")
+	fs.StringVar(&opts.secret, "secret", "", "钉钉加签密钥 / 飞书签名校验密钥 / 通用 Webhook HMAC 签名密钥")
+	fs.StringVar(&opts.keyword, "keyword", "", "钉钉机器人自定义关键词")
+	fs.StringVar(&opts.atMobiles, "at-mobiles", "", "钉钉需要 @ 的成员手机号，多个以逗号分隔")
+	fs.BoolVar(&opts.atAll, "at-all", false, "钉钉消息 @ 所有人")
+	fs.StringVar(&opts.linkURL, "link-url", "", "飞书 / Teams 卡片中按钮的跳转地址")
+	fs.StringVar(&opts.serverURL, "server-url", "", "Bark / ntfy / Gotify / Home Assistant / signal-cli-rest-api 服务地址、Matrix homeserver 地址或 MQTT broker 地址")
+	fs.StringVar(&opts.deviceKeys, "device-keys", "", "Bark 设备 key，多个以逗号分隔")
+	fs.StringVar(&opts.group, "group", "", "Bark 推送分组")
+	fs.StringVar(&opts.sound, "sound", "", "Bark / Pushover 推送铃声")
+	fs.StringVar(&opts.interruptionLevel, "interruption-level", "", "Bark 中断级别（active / timeSensitive / passive）")
+	fs.StringVar(&opts.iconURL, "icon-url", "", "Bark 推送图标地址，或 Mattermost / Rocket.Chat 头像地址")
+	fs.StringVar(&opts.clickURL, "click-url", "", "Bark / ntfy 点击推送后跳转的地址")
+	fs.StringVar(&opts.encryptKey, "encrypt-key", "", "Bark 加密推送的 AES 密钥（16/24/32 位）")
+	fs.StringVar(&opts.encryptIV, "encrypt-iv", "", "Bark 加密推送的 AES IV（16 位）")
+	fs.StringVar(&opts.topic, "topic", "", "ntfy 主题 / PushPlus 群组编码 / MQTT 发布主题模板")
+	fs.StringVar(&opts.password, "password", "", "ntfy / SMTP / MQTT 认证密码")
+	fs.IntVar(&opts.priority, "priority", 0, "消息优先级：ntfy 1-5 / Gotify 0-10 / Pushover -2-2 / Opsgenie 1-5")
+	fs.StringVar(&opts.tags, "tags", "", "ntfy 消息标签，多个以逗号分隔")
+	fs.StringVar(&opts.actions, "actions", "", "ntfy / Home Assistant 操作按钮，格式为 名称=地址，多个以分号分隔")
+	fs.StringVar(&opts.userKey, "user-key", "", "Pushover 用户或群组 key")
+	fs.StringVar(&opts.device, "device", "", "Pushover 目标设备名称")
+	fs.IntVar(&opts.retry, "retry", 0, "Pushover 紧急通知重复提醒间隔（秒），默认为 60")
+	fs.IntVar(&opts.expire, "expire", 0, "Pushover 紧急通知持续提醒时长（秒），默认为 3600")
+	fs.StringVar(&opts.smtpHost, "smtp-host", "", "SMTP 服务器地址")
+	fs.IntVar(&opts.smtpPort, "smtp-port", 0, "SMTP 端口，默认根据加密方式选择 587 / 465 / 25")
+	fs.StringVar(&opts.smtpSecurity, "smtp-security", "", "SMTP 加密方式（starttls / tls / none），默认为 starttls")
+	fs.StringVar(&opts.from, "from", "", "邮件发件人 / Twilio 或 Signal 发送号码")
+	fs.StringVar(&opts.to, "to", "", "邮件收件人 / Twilio 或 Signal 接收号码，多个以逗号分隔")
+	fs.StringVar(&opts.cc, "cc", "", "邮件抄送人，多个以逗号分隔")
+	fs.StringVar(&opts.httpMethod, "http-method", "", "通用 Webhook 的 HTTP 方法，默认为 POST")
+	fs.Var(&opts.headers, "header", "通用 Webhook 请求头，格式为 Key: Value，可重复指定")
+	fs.StringVar(&opts.bodyTemplate, "body-template", "", "通用 Webhook 请求体模板（Go text/template）")
+	fs.StringVar(&opts.successCodes, "success-codes", "", "通用 Webhook 视为成功的状态码，多个以逗号分隔，默认为任意 2xx")
+	fs.StringVar(&opts.signatureHeader, "signature-header", "", "通用 Webhook 签名请求头，默认为 X-Notify-Signature")
+	fs.StringVar(&opts.roomID, "room-id", "", "Matrix 房间 ID，例如 !abc:example.org")
+	fs.StringVar(&opts.protocol, "protocol", "", "终端通知协议（osc9 / osc777 / osc99），默认为 osc9")
+	fs.StringVar(&opts.passthrough, "passthrough", "", "终端复用器透传方式（auto / tmux / screen / none），默认为 auto")
+	fs.StringVar(&opts.tty, "tty", "", "终端通知写入的设备，默认为当前控制终端")
+	fs.StringVar(&opts.command, "command", "", "通知时执行的命令")
+	fs.Var(&opts.args, "arg", "命令参数，可重复指定，按顺序传递")
+	fs.IntVar(&opts.timeout, "timeout", 0, "命令超时时间（秒），默认为 30")
+	fs.StringVar(&opts.path, "path", "", "JSONL 日志文件路径")
+	fs.IntVar(&opts.maxSize, "max-size", config.DefaultLogMaxSizeMB, "日志文件轮转大小（MB），0 表示不轮转")
+	fs.IntVar(&opts.maxBackups, "max-backups", config.DefaultLogMaxBackups, "日志文件保留的历史文件数量")
+	fs.StringVar(&opts.backend, "backend", "", "系统日志后端（auto / syslog / journald），默认为 auto")
+	fs.StringVar(&opts.ident, "ident", "", "系统日志标识，默认为 notify-mcp")
+	fs.StringVar(&opts.clientID, "client-id", "", "MQTT 客户端 ID，默认随机生成")
+	fs.IntVar(&opts.qos, "qos", 0, "MQTT 消息 QoS（0 / 1）")
+	fs.BoolVar(&opts.retain, "retain", false, "MQTT 消息设置 retain 标志")
+	fs.StringVar(&opts.caFile, "ca-file", "", "MQTT TLS 连接使用的 CA 证书文件")
+	fs.StringVar(&opts.service, "service", "", "Home Assistant notify 服务名，例如 mobile_app_pixel_8")
+	fs.StringVar(&opts.pushPriority, "push-priority", "", "Home Assistant 推送优先级（normal / high）")
+	fs.StringVar(&opts.tag, "tag", "", "Home Assistant 通知 tag，相同 tag 的通知会相互替换")
+	fs.StringVar(&opts.severities, "severities", "", "PagerDuty 严重级别映射，格式为 级别=严重级别，多个以逗号分隔")
+	fs.StringVar(&opts.minLevel, "min-level", "", "PagerDuty / Opsgenie 触发告警的最低通知级别（info / warning / error / critical），默认为 error")
+	fs.StringVar(&opts.region, "region", "", "Opsgenie 账号区域（us / eu），默认为 us")
+	fs.StringVar(&opts.responders, "responders", "", "Opsgenie 响应者，格式为 类型:名称，多个以逗号分隔")
+	fs.StringVar(&opts.accountSID, "account-sid", "", "Twilio Account SID")
+	fs.BoolVar(&opts.voice, "voice", false, "Twilio 在 critical 级别通知时额外拨打语音电话")
+	fs.StringVar(&opts.voiceLanguage, "voice-language", "", "Twilio 语音播报语言，例如 cmn-CN")
+	fs.StringVar(&opts.groupIDs, "group-ids", "", "Signal 群组 ID（形如 group.xxxx），多个以逗号分隔")
+}
+
+// methodFlagsSet 返回命令行中显式设置的通知方式专属参数名。
+func methodFlagsSet(fs *flag.FlagSet) []string {
+	var names []string
+	fs.Visit(func(f *flag.Flag) {
+		if !commonFlags[f.Name] {
+			names = append(names, f.Name)
+		}
+	})
+	return names
+}
+
+// checkMethodFlags 确保只提供了当前通知方式支持的参数，并按通知方式校验参数取值。
+// 同名参数在不同通知方式下含义不同（例如 --priority），因此取值规则以通知方式区分。
+func checkMethodFlags(methodType config.MethodType, opts methodOptions, setFlags []string, allowed ...string) error {
+	var unexpected []string
+	for _, name := range setFlags {
+		supported := false
+		for _, item := range allowed {
+			if item == name {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			unexpected = append(unexpected, "--"+name)
+		}
+	}
+	if len(unexpected) > 0 {
+		if len(allowed) == 0 {
+			return fmt.Errorf("通知方式 %s 不支持 %s 参数", methodType, strings.Join(unexpected, "/"))
+		}
+		return fmt.Errorf("通知方式 %s 不支持 %s 参数，可用参数: --%s", methodType, strings.Join(unexpected, "/"), strings.Join(allowed, ", --"))
+	}
+
+	for _, name := range setFlags {
+		rule, ok := methodFlagRules[methodType][name]
+		if !ok {
+			rule, ok = defaultFlagRules[name]
+		}
+		if !ok || rule == nil {
+			continue
+		}
+		if err := rule(opts); err != nil {
+			return fmt.Errorf("通知方式 %s 的 --%s 参数无效: %w", methodType, name, err)
+		}
+	}
+	return nil
+}
+
+// flagRule 校验参数的取值，返回的错误会直接展示给用户。
+type flagRule func(opts methodOptions) error
+
+// defaultFlagRules 是不依赖通知方式的参数取值规则。
+var defaultFlagRules = map[string]flagRule{
+	"api-url":     httpURLRule(func(o methodOptions) string { return o.apiURL }),
+	"webhook-url": httpURLRule(func(o methodOptions) string { return o.webhookURL }),
+	"server-url":  httpURLRule(func(o methodOptions) string { return o.serverURL }),
+	"link-url":    httpURLRule(func(o methodOptions) string { return o.linkURL }),
+	"click-url":   httpURLRule(func(o methodOptions) string { return o.clickURL }),
+	"icon-url":    httpURLRule(func(o methodOptions) string { return o.iconURL }),
+	"avatar-url":  httpURLRule(func(o methodOptions) string { return o.avatarURL }),
+}
+
+// methodFlagRules 是按通知方式区分的参数取值规则，优先于 defaultFlagRules；
+// 规则为 nil 表示该通知方式下不做额外校验。
+var methodFlagRules = map[config.MethodType]map[string]flagRule{
+	config.MethodWeCom: {
+		"msg-type": oneOfRule(func(o methodOptions) string { return o.msgType }, config.WeComMsgTypeMarkdown, config.WeComMsgTypeText),
+	},
+	config.MethodBark: {
+		"interruption-level": oneOfRule(func(o methodOptions) string { return o.interruptionLevel }, config.BarkLevelActive, config.BarkLevelTimeSensitive, config.BarkLevelPassive),
+	},
+	config.MethodNtfy: {
+		"priority": intRangeRule(func(o methodOptions) int { return o.priority }, 1, 5),
+	},
+	config.MethodGotify: {
+		"priority": intRangeRule(func(o methodOptions) int { return o.priority }, 0, 10),
+	},
+	config.MethodPushover: {
+		"priority": intRangeRule(func(o methodOptions) int { return o.priority }, -2, config.PushoverPriorityEmergency),
+		"retry":    minRule(func(o methodOptions) int { return o.retry }, 30),
+		"expire":   intRangeRule(func(o methodOptions) int { return o.expire }, 1, 10800),
+	},
+	config.MethodPushPlus: {
+		"msg-type": oneOfRule(func(o methodOptions) string { return o.msgType }, config.PushPlusTemplateHTML, config.PushPlusTemplateMarkdown),
+	},
+	config.MethodExec: {
+		"timeout": minRule(func(o methodOptions) int { return o.timeout }, 1),
+	},
+	config.MethodLog: {
+		"max-size":    minRule(func(o methodOptions) int { return o.maxSize }, 0),
+		"max-backups": logBackupsRule,
+	},
+	config.MethodMQTT: {
+		// MQTT broker 地址使用 mqtt:// 等协议，交由配置校验。
+		"server-url": nil,
+		"qos":        intRangeRule(func(o methodOptions) int { return o.qos }, 0, 1),
+	},
+	config.MethodHomeAssistant: {
+		"push-priority": oneOfRule(func(o methodOptions) string { return o.pushPriority }, config.HomeAssistantPriorityNormal, config.HomeAssistantPriorityHigh),
+	},
+	config.MethodPagerDuty: {
+		"min-level": minLevelRule,
+	},
+	config.MethodOpsgenie: {
+		"min-level": minLevelRule,
+		"region":    oneOfRule(func(o methodOptions) string { return o.region }, "us", "eu"),
+		"priority":  intRangeRule(func(o methodOptions) int { return o.priority }, 1, 5),
+	},
+}
+
+var minLevelRule = oneOfRule(func(o methodOptions) string { return o.minLevel }, "info", "warning", "error", "critical")
+
+// logBackupsRule 与 LogConfig.Validate 一致，仅在开启轮转时要求保留至少一个历史文件。
+func logBackupsRule(opts methodOptions) error {
+	least := 0
+	if opts.maxSize > 0 {
+		least = 1
+	}
+	return minRule(func(o methodOptions) int { return o.maxBackups }, least)(opts)
+}
+
+func httpURLRule(value func(methodOptions) string) flagRule {
+	return func(opts methodOptions) error {
+		u, err := url.Parse(value(opts))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%q 不是有效的 http(s) 地址", value(opts))
+		}
+		return nil
+	}
+}
+
+func oneOfRule(value func(methodOptions) string, choices ...string) flagRule {
+	return func(opts methodOptions) error {
+		v := value(opts)
+		for _, choice := range choices {
+			if v == choice {
+				return nil
+			}
+		}
+		return fmt.Errorf("可选值为 %s，当前为 %q", strings.Join(choices, " / "), v)
+	}
+}
+
+func intRangeRule(value func(methodOptions) int, min, max int) flagRule {
+	return func(opts methodOptions) error {
+		if v := value(opts); v < min || v > max {
+			return fmt.Errorf("取值范围为 %d 到 %d，当前为 %d", min, max, v)
+		}
+		return nil
+	}
+}
+
+func minRule(value func(methodOptions) int, min int) flagRule {
+	return func(opts methodOptions) error {
+		if v := value(opts); v < min {
+			return fmt.Errorf("不能小于 %d，当前为 %d", min, v)
+		}
+		return nil
+	}
+}
+
+func buildMethod(methodType config.MethodType, opts methodOptions, setFlags []string) (config.Method, error) {
+	switch methodType {
+	case config.MethodTelegram:
+		if err := checkMethodFlags(methodType, opts, setFlags, "api-url", "chat-id", "token"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.chatID == "" || opts.token == "" {
+			return config.Method{}, errors.New("更新 Telegram 配置时必须提供 --chat-id, --token，可选 --api-url")
+		}
+		apiURL := opts.apiURL
+		if apiURL == "" {
+			apiURL = config.DefaultTelegramAPIBaseURL
+		}
+		return config.NewTelegramMethod(config.TelegramConfig{
+			APIBaseURL: apiURL,
+			ChatID:     opts.chatID,
+			Token:      opts.token,
+		})
+	case config.MethodOS:
+		if err := checkMethodFlags(methodType, opts, setFlags); err != nil {
+			return config.Method{}, err
+		}
+		return config.NewOSMethod()
+	case config.MethodSlack:
+		if err := checkMethodFlags(methodType, opts, setFlags, "webhook-url", "channel", "username", "icon-emoji"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.webhookURL == "" {
+			return config.Method{}, errors.New("更新 Slack 配置时必须提供 --webhook-url，可选 --channel, --username, --icon-emoji")
+		}
+		return config.NewSlackMethod(config.SlackConfig{
+			WebhookURL: opts.webhookURL,
+			Channel:    opts.channel,
+			Username:   opts.username,
+			IconEmoji:  opts.iconEmoji,
+		})
+	case config.MethodDiscord:
+		if err := checkMethodFlags(methodType, opts, setFlags, "webhook-url", "username", "avatar-url"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.webhookURL == "" {
+			return config.Method{}, errors.New("更新 Discord 配置时必须提供 --webhook-url，可选 --username, --avatar-url")
+		}
+		return config.NewDiscordMethod(config.DiscordConfig{
+			WebhookURL: opts.webhookURL,
+			Username:   opts.username,
+			AvatarURL:  opts.avatarURL,
+		})
+	case config.MethodWeCom:
+		if err := checkMethodFlags(methodType, opts, setFlags, "api-url", "key", "msg-type", "mentioned", "mentioned-mobile"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.key == "" {
+			return config.Method{}, errors.New("更新企业微信配置时必须提供 --key，可选 --msg-type, --mentioned, --mentioned-mobile, --api-url")
+		}
+		apiURL := opts.apiURL
+		if apiURL == "" {
+			apiURL = config.DefaultWeComAPIBaseURL
+		}
+		msgType := opts.msgType
+		if msgType == "" {
+			msgType = config.WeComMsgTypeMarkdown
+		}
+		return config.NewWeComMethod(config.WeComConfig{
+			APIBaseURL:          apiURL,
+			Key:                 opts.key,
+			MsgType:             msgType,
+			MentionedList:       splitList(opts.mentioned),
+			MentionedMobileList: splitList(opts.mentionedMobile),
+		})
+	case config.MethodDingTalk:
+		if err := checkMethodFlags(methodType, opts, setFlags, "api-url", "token", "secret", "keyword", "at-mobiles", "at-all"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.token == "" {
+			return config.Method{}, errors.New("更新钉钉配置时必须提供 --token，可选 --secret, --keyword, --at-mobiles, --at-all, --api-url")
+		}
+		apiURL := opts.apiURL
+		if apiURL == "" {
+			apiURL = config.DefaultDingTalkAPIBaseURL
+		}
+		return config.NewDingTalkMethod(config.DingTalkConfig{
+			APIBaseURL:  apiURL,
+			AccessToken: opts.token,
+			Secret:      opts.secret,
+			Keyword:     opts.keyword,
+			AtMobiles:   splitList(opts.atMobiles),
+			AtAll:       opts.atAll,
+		})
+	case config.MethodFeishu:
+		if err := checkMethodFlags(methodType, opts, setFlags, "webhook-url", "secret", "link-url"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.webhookURL == "" {
+			return config.Method{}, errors.New("更新飞书配置时必须提供 --webhook-url，可选 --secret, --link-url")
+		}
+		return config.NewFeishuMethod(config.FeishuConfig{
+			WebhookURL: opts.webhookURL,
+			Secret:     opts.secret,
+			LinkURL:    opts.linkURL,
+		})
+	case config.MethodBark:
+		if err := checkMethodFlags(methodType, opts, setFlags, "server-url", "device-keys", "group", "sound", "interruption-level", "icon-url", "click-url", "encrypt-key", "encrypt-iv"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.deviceKeys == "" {
+			return config.Method{}, errors.New("更新 Bark 配置时必须提供 --device-keys，可选 --server-url, --group, --sound, --interruption-level, --icon-url, --click-url, --encrypt-key, --encrypt-iv")
+		}
+		serverURL := opts.serverURL
+		if serverURL == "" {
+			serverURL = config.DefaultBarkServerURL
+		}
+		return config.NewBarkMethod(config.BarkConfig{
+			ServerURL:     serverURL,
+			DeviceKeys:    splitList(opts.deviceKeys),
+			Group:         opts.group,
+			Sound:         opts.sound,
+			Level:         opts.interruptionLevel,
+			IconURL:       opts.iconURL,
+			ClickURL:      opts.clickURL,
+			EncryptionKey: opts.encryptKey,
+			EncryptionIV:  opts.encryptIV,
+		})
+	case config.MethodNtfy:
+		if err := checkMethodFlags(methodType, opts, setFlags, "server-url", "topic", "username", "password", "token", "priority", "tags", "click-url", "actions"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.topic == "" {
+			return config.Method{}, errors.New("更新 ntfy 配置时必须提供 --topic，可选 --server-url, --username, --password, --token, --priority, --tags, --click-url, --actions")
+		}
+		serverURL := opts.serverURL
+		if serverURL == "" {
+			serverURL = config.DefaultNtfyServerURL
+		}
+		links, err := parseActions(opts.actions)
+		if err != nil {
+			return config.Method{}, err
+		}
+		var actions []config.NtfyAction
+		for _, link := range links {
+			actions = append(actions, config.NtfyAction{Label: link.label, URL: link.url})
+		}
+		return config.NewNtfyMethod(config.NtfyConfig{
+			ServerURL: serverURL,
+			Topic:     opts.topic,
+			Username:  opts.username,
+			Password:  opts.password,
+			Token:     opts.token,
+			Priority:  opts.priority,
+			Tags:      splitList(opts.tags),
+			ClickURL:  opts.clickURL,
+			Actions:   actions,
+		})
+	case config.MethodGotify:
+		if err := checkMethodFlags(methodType, opts, setFlags, "server-url", "token", "priority"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.serverURL == "" || opts.token == "" {
+			return config.Method{}, errors.New("更新 Gotify 配置时必须提供 --server-url, --token，可选 --priority")
+		}
+		cfg := config.GotifyConfig{
+			ServerURL: opts.serverURL,
+			Token:     opts.token,
+		}
+		// 仅在显式指定时写入优先级，以区分静默（0）与使用应用默认优先级。
+		if slices.Contains(setFlags, "priority") {
+			priority := opts.priority
+			cfg.Priority = &priority
+		}
+		return config.NewGotifyMethod(cfg)
+	case config.MethodPushover:
+		if err := checkMethodFlags(methodType, opts, setFlags, "api-url", "token", "user-key", "device", "sound", "priority", "retry", "expire"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.token == "" || opts.userKey == "" {
+			return config.Method{}, errors.New("更新 Pushover 配置时必须提供 --token, --user-key，可选 --device, --sound, --priority, --retry, --expire, --api-url")
+		}
+		apiURL := opts.apiURL
+		if apiURL == "" {
+			apiURL = config.DefaultPushoverAPIBaseURL
+		}
+		cfg := config.PushoverConfig{
+			APIBaseURL: apiURL,
+			Token:      opts.token,
+			UserKey:    opts.userKey,
+			Device:     opts.device,
+			Sound:      opts.sound,
+			Priority:   opts.priority,
+			Retry:      opts.retry,
+			Expire:     opts.expire,
+		}
+		if cfg.Priority == config.PushoverPriorityEmergency {
+			if cfg.Retry == 0 {
+				cfg.Retry = 60
+			}
+			if cfg.Expire == 0 {
+				cfg.Expire = 3600
+			}
+		}
+		return config.NewPushoverMethod(cfg)
+	case config.MethodEmail:
+		if err := checkMethodFlags(methodType, opts, setFlags, "smtp-host", "smtp-port", "smtp-security", "username", "password", "from", "to", "cc"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.smtpHost == "" || opts.from == "" || opts.to == "" {
+			return config.Method{}, errors.New("更新邮件配置时必须提供 --smtp-host, --from, --to，可选 --smtp-port, --smtp-security, --username, --password, --cc")
+		}
+		security := opts.smtpSecurity
+		if security == "" {
+			security = config.EmailSecurityStartTLS
+		}
+		port := opts.smtpPort
+		if port == 0 {
+			port = config.DefaultEmailPort(security)
+		}
+		return config.NewEmailMethod(config.EmailConfig{
+			Host:     opts.smtpHost,
+			Port:     port,
+			Security: security,
+			Username: opts.username,
+			Password: opts.password,
+			From:     opts.from,
+			To:       splitList(opts.to),
+			Cc:       splitList(opts.cc),
+		})
+	case config.MethodWebhook:
+		if err := checkMethodFlags(methodType, opts, setFlags, "webhook-url", "http-method", "header", "body-template", "success-codes", "secret", "signature-header"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.webhookURL == "" {
+			return config.Method{}, errors.New("更新通用 Webhook 配置时必须提供 --webhook-url，可选 --http-method, --header, --body-template, --success-codes, --secret, --signature-header")
+		}
+		httpMethod := strings.ToUpper(opts.httpMethod)
+		if httpMethod == "" {
+			httpMethod = http.MethodPost
+		}
+		headers, err := parseHeaders(opts.headers)
+		if err != nil {
+			return config.Method{}, err
+		}
+		codes, err := parseStatusCodes(opts.successCodes)
+		if err != nil {
+			return config.Method{}, err
+		}
+		return config.NewWebhookMethod(config.WebhookConfig{
+			URL:                opts.webhookURL,
+			Method:             httpMethod,
+			Headers:            headers,
+			BodyTemplate:       opts.bodyTemplate,
+			SuccessStatusCodes: codes,
+			SignatureSecret:    opts.secret,
+			SignatureHeader:    opts.signatureHeader,
+		})
+	case config.MethodTeams:
+		if err := checkMethodFlags(methodType, opts, setFlags, "webhook-url", "link-url"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.webhookURL == "" {
+			return config.Method{}, errors.New("更新 Teams 配置时必须提供 --webhook-url，可选 --link-url")
+		}
+		return config.NewTeamsMethod(config.TeamsConfig{
+			WebhookURL: opts.webhookURL,
+			LinkURL:    opts.linkURL,
+		})
+	case config.MethodMatrix:
+		if err := checkMethodFlags(methodType, opts, setFlags, "server-url", "token", "room-id"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.serverURL == "" || opts.token == "" || opts.roomID == "" {
+			return config.Method{}, errors.New("更新 Matrix 配置时必须提供 --server-url, --token, --room-id")
+		}
+		return config.NewMatrixMethod(config.MatrixConfig{
+			HomeserverURL: opts.serverURL,
+			AccessToken:   opts.token,
+			RoomID:        opts.roomID,
+		})
+	case config.MethodMattermost:
+		if err := checkMethodFlags(methodType, opts, setFlags, "webhook-url", "channel", "username", "icon-url", "icon-emoji"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.webhookURL == "" {
+			return config.Method{}, errors.New("更新 Mattermost 配置时必须提供 --webhook-url，可选 --channel, --username, --icon-url, --icon-emoji")
+		}
+		return config.NewMattermostMethod(config.MattermostConfig{
+			WebhookURL: opts.webhookURL,
+			Channel:    opts.channel,
+			Username:   opts.username,
+			IconURL:    opts.iconURL,
+			IconEmoji:  opts.iconEmoji,
+		})
+	case config.MethodRocketChat:
+		if err := checkMethodFlags(methodType, opts, setFlags, "webhook-url", "channel", "username", "icon-url", "icon-emoji"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.webhookURL == "" {
+			return config.Method{}, errors.New("更新 Rocket.Chat 配置时必须提供 --webhook-url，可选 --channel, --username, --icon-url, --icon-emoji")
+		}
+		return config.NewRocketChatMethod(config.RocketChatConfig{
+			WebhookURL: opts.webhookURL,
+			Channel:    opts.channel,
+			Username:   opts.username,
+			IconURL:    opts.iconURL,
+			IconEmoji:  opts.iconEmoji,
+		})
+	case config.MethodGoogleChat:
+		if err := checkMethodFlags(methodType, opts, setFlags, "webhook-url"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.webhookURL == "" {
+			return config.Method{}, errors.New("更新 Google Chat 配置时必须提供 --webhook-url")
+		}
+		return config.NewGoogleChatMethod(config.GoogleChatConfig{
+			WebhookURL: opts.webhookURL,
+		})
+	case config.MethodServerChan:
+		if err := checkMethodFlags(methodType, opts, setFlags, "key", "api-url"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.key == "" {
+			return config.Method{}, errors.New("更新 Server酱 配置时必须提供 --key，可选 --api-url")
+		}
+		return config.NewServerChanMethod(config.ServerChanConfig{
+			SendKey:    opts.key,
+			APIBaseURL: opts.apiURL,
+		})
+	case config.MethodPushPlus:
+		if err := checkMethodFlags(methodType, opts, setFlags, "token", "topic", "msg-type", "api-url"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.token == "" {
+			return config.Method{}, errors.New("更新 PushPlus 配置时必须提供 --token，可选 --topic, --msg-type, --api-url")
+		}
+		apiURL := opts.apiURL
+		if apiURL == "" {
+			apiURL = config.DefaultPushPlusAPIBaseURL
+		}
+		template := opts.msgType
+		if template == "" {
+			template = config.PushPlusTemplateHTML
+		}
+		return config.NewPushPlusMethod(config.PushPlusConfig{
+			APIBaseURL: apiURL,
+			Token:      opts.token,
+			Topic:      opts.topic,
+			Template:   template,
+		})
+	case config.MethodTerminal:
+		if err := checkMethodFlags(methodType, opts, setFlags, "protocol", "passthrough", "tty"); err != nil {
+			return config.Method{}, err
+		}
+		protocol := opts.protocol
+		if protocol == "" {
+			protocol = config.TerminalProtocolOSC9
+		}
+		passthrough := opts.passthrough
+		if passthrough == "" {
+			passthrough = config.TerminalPassthroughAuto
+		}
+		return config.NewTerminalMethod(config.TerminalConfig{
+			Protocol:    protocol,
+			Passthrough: passthrough,
+			TTY:         opts.tty,
+		})
+	case config.MethodExec:
+		if err := checkMethodFlags(methodType, opts, setFlags, "command", "arg", "timeout"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.command == "" {
+			return config.Method{}, errors.New("更新命令执行配置时必须提供 --command，可选 --arg, --timeout")
+		}
+		return config.NewExecMethod(config.ExecConfig{
+			Command:        opts.command,
+			Args:           opts.args,
+			TimeoutSeconds: opts.timeout,
+		})
+	case config.MethodLog:
+		if err := checkMethodFlags(methodType, opts, setFlags, "path", "max-size", "max-backups"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.path == "" {
+			return config.Method{}, errors.New("更新日志文件配置时必须提供 --path，可选 --max-size, --max-backups")
+		}
+		// MCP 服务的工作目录不固定，相对路径需在配置时转换为绝对路径。
+		path, err := filepath.Abs(opts.path)
+		if err != nil {
+			return config.Method{}, fmt.Errorf("解析日志文件路径失败: %w", err)
+		}
+		return config.NewLogMethod(config.LogConfig{
+			Path:       path,
+			MaxSizeMB:  opts.maxSize,
+			MaxBackups: opts.maxBackups,
+		})
+	case config.MethodSyslog:
+		if err := checkMethodFlags(methodType, opts, setFlags, "backend", "ident"); err != nil {
+			return config.Method{}, err
+		}
+		backend := opts.backend
+		if backend == "" {
+			backend = config.SyslogBackendAuto
+		}
+		ident := opts.ident
+		if ident == "" {
+			ident = config.DefaultSyslogIdent
+		}
+		return config.NewSyslogMethod(config.SyslogConfig{
+			Backend: backend,
+			Ident:   ident,
+		})
+	case config.MethodMQTT:
+		if err := checkMethodFlags(methodType, opts, setFlags, "server-url", "username", "password", "client-id", "topic", "qos", "retain", "ca-file"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.serverURL == "" {
+			return config.Method{}, errors.New("更新 MQTT 配置时必须提供 --server-url，可选 --username, --password, --client-id, --topic, --qos, --retain, --ca-file")
+		}
+		topic := opts.topic
+		if topic == "" {
+			topic = config.DefaultMQTTTopic
+		}
+		caFile := opts.caFile
+		if caFile != "" {
+			abs, err := filepath.Abs(caFile)
+			if err != nil {
+				return config.Method{}, fmt.Errorf("解析 CA 证书路径失败: %w", err)
+			}
+			caFile = abs
+		}
+		return config.NewMQTTMethod(config.MQTTConfig{
+			BrokerURL: opts.serverURL,
+			Username:  opts.username,
+			Password:  opts.password,
+			ClientID:  opts.clientID,
+			Topic:     topic,
+			QoS:       opts.qos,
+			Retain:    opts.retain,
+			CAFile:    caFile,
+		})
+	case config.MethodHomeAssistant:
+		if err := checkMethodFlags(methodType, opts, setFlags, "server-url", "token", "service", "push-priority", "tag", "actions"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.serverURL == "" || opts.token == "" || opts.service == "" {
+			return config.Method{}, errors.New("更新 Home Assistant 配置时必须提供 --server-url, --token, --service，可选 --push-priority, --tag, --actions")
+		}
+		links, err := parseActions(opts.actions)
+		if err != nil {
+			return config.Method{}, err
+		}
+		var actions []config.HomeAssistantAction
+		for _, link := range links {
+			actions = append(actions, config.HomeAssistantAction{Title: link.label, URI: link.url})
+		}
+		return config.NewHomeAssistantMethod(config.HomeAssistantConfig{
+			ServerURL: opts.serverURL,
+			Token:     opts.token,
+			// 允许直接粘贴 Home Assistant 中显示的 notify.xxx 完整服务名。
+			Service:  strings.TrimPrefix(opts.service, "notify."),
+			Priority: opts.pushPriority,
+			Tag:      opts.tag,
+			Actions:  actions,
+		})
+	case config.MethodPagerDuty:
+		if err := checkMethodFlags(methodType, opts, setFlags, "key", "severities", "min-level", "api-url"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.key == "" {
+			return config.Method{}, errors.New("更新 PagerDuty 配置时必须提供 --key，可选 --severities, --min-level, --api-url")
+		}
+		apiURL := opts.apiURL
+		if apiURL == "" {
+			apiURL = config.DefaultPagerDutyAPIBaseURL
+		}
+		severities, err := parseSeverities(opts.severities)
+		if err != nil {
+			return config.Method{}, err
+		}
+		return config.NewPagerDutyMethod(config.PagerDutyConfig{
+			APIBaseURL: apiURL,
+			RoutingKey: opts.key,
+			Severities: severities,
+			MinLevel:   opts.minLevel,
+		})
+	case config.MethodOpsgenie:
+		if err := checkMethodFlags(methodType, opts, setFlags, "key", "region", "responders", "priority", "min-level", "api-url"); err != nil {
+			return config.Method{}, err
+		}
+		if opts.key == "" {
+			return config.Method{}, errors.New("更新 Opsgenie 配置时必须提供 --key，可选 --region, --responders, --priority, --min-level, --api-url")
+		}
+		apiURL := opts.apiURL
+		if apiURL == "" {
+			switch opts.region {
+			case "", "us":
+				apiURL = config.OpsgenieAPIBaseURLUS
+			case "eu":
+				apiURL = config.OpsgenieAPIBaseURLEU
+			default:
+				return config.Method{}, fmt.Errorf("不支持的 Opsgenie 区域: %s", opts.region)
+			}
+		}
+		var priority string
+		if opts.priority != 0 {
+			priority = fmt.Sprintf("P%d", opts.priority)
+		}
+		responders, err := parseResponders(opts.responders)
+		if err != nil {
+			return config.Method{}, err
+		}
+		return config.NewOpsgenieMethod(config.OpsgenieConfig{
+			APIBaseURL: apiURL,
+			APIKey:     opts.key,
+			Responders: responders,
+			Priority:   priority,
+			MinLevel:   opts.minLevel,
+		})
+	case config.MethodTwilio:
+		if err := checkMethodFlags(methodType, opts, setFlags, "account-sid", "token", "from", "to", "voice", "voice-language", "api-url"); err != nil {
+			return config.Method{}, err
+		}
+		to := splitList(opts.to)
+		if opts.accountSID == "" || opts.token == "" || opts.from == "" || len(to) == 0 {
+			return config.Method{}, errors.New("更新 Twilio 配置时必须提供 --account-sid, --token, --from, --to，可选 --voice, --voice-language, --api-url")
+		}
+		if opts.voiceLanguage != "" && !opts.voice {
+			return config.Method{}, errors.New("--voice-language 需要与 --voice 一起使用")
+		}
+		apiURL := opts.apiURL
+		if apiURL == "" {
+			apiURL = config.DefaultTwilioAPIBaseURL
+		}
+		return config.NewTwilioMethod(config.TwilioConfig{
+			APIBaseURL:      apiURL,
+			AccountSID:      opts.accountSID,
+			AuthToken:       opts.token,
+			From:            opts.from,
+			To:              to,
+			VoiceOnCritical: opts.voice,
+			VoiceLanguage:   opts.voiceLanguage,
+		})
+	case config.MethodSignal:
+		if err := checkMethodFlags(methodType, opts, setFlags, "server-url", "from", "to", "group-ids"); err != nil {
+			return config.Method{}, err
+		}
+		recipients := splitList(opts.to)
+		groupIDs := splitList(opts.groupIDs)
+		if opts.serverURL == "" || opts.from == "" || (len(recipients) == 0 && len(groupIDs) == 0) {
+			return config.Method{}, errors.New("更新 Signal 配置时必须提供 --server-url, --from，以及 --to 或 --group-ids 至少一项")
+		}
+		return config.NewSignalMethod(config.SignalConfig{
+			ServerURL:  opts.serverURL,
+			Number:     opts.from,
+			Recipients: recipients,
+			GroupIDs:   groupIDs,
+		})
+	default:
+		return config.Method{}, fmt.Errorf("不支持的通知方式: %s", methodType)
+	}
+}
+
+// splitList 将逗号分隔的参数拆分为去除空白后的列表。
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// linkAction 是 --actions 参数中的一个按钮，由各通知方式转换为自己的配置结构。
+type linkAction struct {
+	label string
+	url   string
+}
+
+// parseActions 解析 "名称=地址;名称=地址" 形式的操作按钮参数。
+func parseActions(value string) ([]linkAction, error) {
+	var actions []linkAction
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		label, link, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("无法解析操作按钮 %q，格式应为 名称=地址", item)
+		}
+		actions = append(actions, linkAction{
+			label: strings.TrimSpace(label),
+			url:   strings.TrimSpace(link),
+		})
+	}
+	return actions, nil
+}
+
+// parseHeaders 解析 "Key: Value" 形式的请求头参数。
+func parseHeaders(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	headers := make(map[string]string, len(values))
+	for _, item := range values {
+		key, value, ok := strings.Cut(item, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("无法解析请求头 %q，格式应为 Key: Value", item)
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// parseSeverities 解析 "级别=严重级别,级别=严重级别" 形式的 PagerDuty 映射参数。
+func parseSeverities(value string) (map[string]string, error) {
+	items := splitList(value)
+	if len(items) == 0 {
+		return nil, nil
+	}
+	severities := make(map[string]string, len(items))
+	for _, item := range items {
+		level, severity, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("无法解析严重级别映射 %q，格式应为 级别=严重级别", item)
+		}
+		severities[strings.TrimSpace(level)] = strings.TrimSpace(severity)
+	}
+	return severities, nil
+}
+
+// parseResponders 解析 "类型:名称,类型:名称" 形式的 Opsgenie 响应者参数。
+func parseResponders(value string) ([]config.OpsgenieResponder, error) {
+	var responders []config.OpsgenieResponder
+	for _, item := range splitList(value) {
+		kind, name, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("无法解析响应者 %q，格式应为 类型:名称", item)
+		}
+		responders = append(responders, config.OpsgenieResponder{
+			Type: strings.TrimSpace(kind),
+			Name: strings.TrimSpace(name),
+		})
+	}
+	return responders, nil
+}
+
+// parseStatusCodes 解析逗号分隔的 HTTP 状态码列表。
+func parseStatusCodes(value string) ([]int, error) {
+	var codes []int
+	for _, item := range splitList(value) {
+		code, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, fmt.Errorf("无法解析状态码 %q", item)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
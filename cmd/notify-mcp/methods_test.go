@@ -0,0 +1,119 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+)
+
+func TestBuildMethodValidatesFlagsPerMethod(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   config.MethodType
+		opts     methodOptions
+		setFlags []string
+		wantErr  string
+	}{
+		{
+			name:     "ntfy priority out of range",
+			method:   config.MethodNtfy,
+			opts:     methodOptions{topic: "alerts", priority: 7},
+			setFlags: []string{"topic", "priority"},
+			wantErr:  "--priority 参数无效: 取值范围为 1 到 5",
+		},
+		{
+			name:     "gotify accepts silent priority",
+			method:   config.MethodGotify,
+			opts:     methodOptions{serverURL: "https://gotify.example.com", token: "t", priority: 0},
+			setFlags: []string{"server-url", "token", "priority"},
+		},
+		{
+			name:     "pushover priority out of range",
+			method:   config.MethodPushover,
+			opts:     methodOptions{token: "t", userKey: "u", priority: 5},
+			setFlags: []string{"token", "user-key", "priority"},
+			wantErr:  "取值范围为 -2 到 2",
+		},
+		{
+			name:     "pushplus rejects wecom msg type",
+			method:   config.MethodPushPlus,
+			opts:     methodOptions{token: "t", msgType: "text"},
+			setFlags: []string{"token", "msg-type"},
+			wantErr:  "可选值为 html / markdown",
+		},
+		{
+			name:     "invalid api url",
+			method:   config.MethodTelegram,
+			opts:     methodOptions{chatID: "1", token: "t", apiURL: "api.telegram.org"},
+			setFlags: []string{"chat-id", "token", "api-url"},
+			wantErr:  "不是有效的 http(s) 地址",
+		},
+		{
+			name:     "mqtt broker url is not http",
+			method:   config.MethodMQTT,
+			opts:     methodOptions{serverURL: "mqtt://broker:1883"},
+			setFlags: []string{"server-url"},
+		},
+		{
+			name:     "log rotation requires a backup",
+			method:   config.MethodLog,
+			opts:     methodOptions{path: "/var/log/notify.jsonl", maxSize: 10, maxBackups: 0},
+			setFlags: []string{"path", "max-size", "max-backups"},
+			wantErr:  "--max-backups 参数无效: 不能小于 1",
+		},
+		{
+			name:     "log without rotation needs no backup",
+			method:   config.MethodLog,
+			opts:     methodOptions{path: "/var/log/notify.jsonl", maxSize: 0, maxBackups: 0},
+			setFlags: []string{"path", "max-size", "max-backups"},
+		},
+		{
+			name:     "wecom markdown cannot mention all",
+			method:   config.MethodWeCom,
+			opts:     methodOptions{key: "k", mentioned: "zhangsan,@all"},
+			setFlags: []string{"key", "mentioned"},
+			wantErr:  "@all requires text msg type",
+		},
+		{
+			name:     "wecom text can mention all",
+			method:   config.MethodWeCom,
+			opts:     methodOptions{key: "k", msgType: "text", mentioned: "@all"},
+			setFlags: []string{"key", "msg-type", "mentioned"},
+		},
+		{
+			name:     "pagerduty rejects unknown min level",
+			method:   config.MethodPagerDuty,
+			opts:     methodOptions{key: "k", minLevel: "success"},
+			setFlags: []string{"key", "min-level"},
+			wantErr:  "--min-level 参数无效",
+		},
+		{
+			name:     "opsgenie accepts warning min level",
+			method:   config.MethodOpsgenie,
+			opts:     methodOptions{key: "k", minLevel: "warning"},
+			setFlags: []string{"key", "min-level"},
+		},
+		{
+			name:     "unsupported flag is rejected",
+			method:   config.MethodGotify,
+			opts:     methodOptions{serverURL: "https://gotify.example.com", token: "t", topic: "x"},
+			setFlags: []string{"server-url", "token", "topic"},
+			wantErr:  "不支持 --topic 参数",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := buildMethod(c.method, c.opts, c.setFlags)
+			if c.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ go 1.23.0
 require (
 	git.sr.ht/~jackmordaunt/go-toast v1.1.2
 	github.com/deckarep/gosx-notifier v0.0.0-20180201035817-e127226297fb
+	github.com/godbus/dbus/v5 v5.1.0
 	github.com/mark3labs/mcp-go v0.43.0
 )
 
@@ -0,0 +1,128 @@
+package bark
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type payload struct {
+	Title      string   `json:"title"`
+	Body       string   `json:"body"`
+	DeviceKey  string   `json:"device_key,omitempty"`
+	DeviceKeys []string `json:"device_keys,omitempty"`
+	Group      string   `json:"group,omitempty"`
+	Sound      string   `json:"sound,omitempty"`
+	Level      string   `json:"level,omitempty"`
+	Icon       string   `json:"icon,omitempty"`
+	URL        string   `json:"url,omitempty"`
+}
+
+type response struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// SendMessage pushes the notification to the configured Bark devices.
+func SendMessage(ctx context.Context, cfg config.BarkConfig, msg notify.Message) error {
+	client := &http.Client{Timeout: 15 * time.Second}
+	base := strings.TrimRight(cfg.ServerURL, "/")
+	p := buildPayload(cfg, msg)
+
+	if cfg.EncryptionKey == "" {
+		if len(cfg.DeviceKeys) == 1 {
+			p.DeviceKey = cfg.DeviceKeys[0]
+		} else {
+			p.DeviceKeys = cfg.DeviceKeys
+		}
+		body, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("encode bark payload: %w", err)
+		}
+		return post(ctx, client, base+"/push", "application/json", body)
+	}
+
+	// 加密推送时设备 key 位于路径中，只能逐个设备发送。
+	ciphertext, err := encrypt(cfg, p)
+	if err != nil {
+		return err
+	}
+	form := url.Values{}
+	form.Set("ciphertext", ciphertext)
+	form.Set("iv", cfg.EncryptionIV)
+	for _, key := range cfg.DeviceKeys {
+		endpoint := base + "/" + url.PathEscape(key)
+		if err := post(ctx, client, endpoint, "application/x-www-form-urlencoded", []byte(form.Encode())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func post(ctx context.Context, client *http.Client, endpoint, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build bark request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call bark: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var result response
+	if err := json.Unmarshal(data, &result); err == nil && result.Code != 0 && result.Code != http.StatusOK {
+		return fmt.Errorf("bark responded with code %d: %s", result.Code, result.Message)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("bark responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// encrypt 使用 AES-CBC + PKCS7 填充加密推送内容，返回 Base64 编码的密文。
+func encrypt(cfg config.BarkConfig, p payload) (string, error) {
+	plaintext, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("encode bark payload: %w", err)
+	}
+
+	block, err := aes.NewCipher([]byte(cfg.EncryptionKey))
+	if err != nil {
+		return "", fmt.Errorf("init bark cipher: %w", err)
+	}
+
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	plaintext = append(plaintext, bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, []byte(cfg.EncryptionIV)).CryptBlocks(ciphertext, plaintext)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func buildPayload(cfg config.BarkConfig, msg notify.Message) payload {
+	return payload{
+		Title: msg.TaskName,
+		Body:  fmt.Sprintf("%s\n时间：%s", msg.Body, msg.FormattedTime()),
+		Group: cfg.Group,
+		Sound: cfg.Sound,
+		Level: cfg.Level,
+		Icon:  cfg.IconURL,
+		URL:   cfg.ClickURL,
+	}
+}
@@ -0,0 +1,156 @@
+package bark
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageEncrypted(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.BarkConfig{
+		DeviceKeys:    []string{"device-a"},
+		Group:         "notify-mcp",
+		EncryptionKey: "0123456789abcdef",
+		EncryptionIV:  "fedcba9876543210",
+	}
+
+	var got payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/device-a" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if iv := r.FormValue("iv"); iv != cfg.EncryptionIV {
+			t.Errorf("unexpected iv %q", iv)
+		}
+
+		ciphertext, err := base64.StdEncoding.DecodeString(r.FormValue("ciphertext"))
+		if err != nil {
+			t.Errorf("decode ciphertext: %v", err)
+			return
+		}
+		block, _ := aes.NewCipher([]byte(cfg.EncryptionKey))
+		plaintext := make([]byte, len(ciphertext))
+		cipher.NewCBCDecrypter(block, []byte(cfg.EncryptionIV)).CryptBlocks(plaintext, ciphertext)
+		padding := int(plaintext[len(plaintext)-1])
+		if err := json.Unmarshal(plaintext[:len(plaintext)-padding], &got); err != nil {
+			t.Errorf("decode plaintext: %v", err)
+		}
+
+		_, _ = w.Write([]byte(`{"code":200,"message":"success"}`))
+	}))
+	defer srv.Close()
+
+	cfg.ServerURL = srv.URL
+	msg := notify.Message{Time: time.Now(), TaskName: "部署", Body: "请确认"}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if got.Title != "部署" || got.Group != "notify-mcp" || !bytes.Contains([]byte(got.Body), []byte("请确认")) {
+		t.Fatalf("unexpected decrypted payload: %+v", got)
+	}
+	if got.DeviceKey != "" || len(got.DeviceKeys) != 0 {
+		t.Fatalf("device key must not be part of encrypted payload: %+v", got)
+	}
+}
+
+func TestSendMessagePlain(t *testing.T) {
+	t.Parallel()
+
+	var got []payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/push" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		var p payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		got = append(got, p)
+		_, _ = w.Write([]byte(`{"code":200,"message":"success"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.BarkConfig{
+		ServerURL:  srv.URL + "/",
+		DeviceKeys: []string{"device-a"},
+		Sound:      "alarm",
+		Level:      config.BarkLevelTimeSensitive,
+		IconURL:    "https://example.com/icon.png",
+		ClickURL:   "https://ci.example.com/build/1",
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "部署", Body: "完成"}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("single device SendMessage returned error: %v", err)
+	}
+	cfg.DeviceKeys = []string{"device-a", "device-b"}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("multi device SendMessage returned error: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(got))
+	}
+	single, multi := got[0], got[1]
+	if single.DeviceKey != "device-a" || len(single.DeviceKeys) != 0 {
+		t.Fatalf("single device should use device_key: %+v", single)
+	}
+	if single.Title != "部署" || single.Sound != "alarm" || single.Level != config.BarkLevelTimeSensitive ||
+		single.Icon != cfg.IconURL || single.URL != cfg.ClickURL {
+		t.Fatalf("unexpected payload: %+v", single)
+	}
+	if multi.DeviceKey != "" || len(multi.DeviceKeys) != 2 || multi.DeviceKeys[1] != "device-b" {
+		t.Fatalf("multiple devices should use device_keys: %+v", multi)
+	}
+}
+
+func TestSendMessageError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p payload
+		_ = json.NewDecoder(r.Body).Decode(&p)
+		switch p.DeviceKey {
+		case "bad-key":
+			// Bark 对未注册的设备返回 HTTP 400 与业务错误码。
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":400,"message":"failed to get device token: failed to get [bad-key] device token from database"}`))
+		case "proxy":
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`<html>502 Bad Gateway</html>`))
+		default:
+			_, _ = w.Write([]byte(`{"code":500,"message":"push failed"}`))
+		}
+	}))
+	defer srv.Close()
+
+	msg := notify.Message{Time: time.Now(), TaskName: "部署", Body: "完成"}
+	cases := map[string]string{
+		"bad-key":  "code 400: failed to get device token",
+		"proxy":    "502 Bad Gateway",
+		"device-a": "code 500: push failed",
+	}
+	for key, want := range cases {
+		cfg := config.BarkConfig{ServerURL: srv.URL, DeviceKeys: []string{key}}
+		err := SendMessage(context.Background(), cfg, msg)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got %v", key, want, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultBarkServerURL 是 Bark 官方推送服务地址。
+const DefaultBarkServerURL = "https://api.day.app"
+
+// Bark 支持的通知中断级别。
+const (
+	BarkLevelActive        = "active"
+	BarkLevelTimeSensitive = "timeSensitive"
+	BarkLevelPassive       = "passive"
+)
+
+// BarkConfig holds the Bark push configuration values.
+type BarkConfig struct {
+	ServerURL  string   `json:"serverUrl"`
+	DeviceKeys []string `json:"deviceKeys"`
+	Group      string   `json:"group,omitempty"`
+	Sound      string   `json:"sound,omitempty"`
+	Level      string   `json:"level,omitempty"`
+	IconURL    string   `json:"iconUrl,omitempty"`
+	ClickURL   string   `json:"clickUrl,omitempty"`
+	// EncryptionKey 与 EncryptionIV 非空时使用 AES-CBC 加密推送，需与 App 中的设置一致。
+	EncryptionKey string `json:"encryptionKey,omitempty"`
+	EncryptionIV  string `json:"encryptionIv,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c BarkConfig) Validate() error {
+	if c.ServerURL == "" {
+		return errors.New("missing bark server url")
+	}
+	if err := validateHTTPURL(c.ServerURL); err != nil {
+		return fmt.Errorf("invalid bark server url: %w", err)
+	}
+	if len(c.DeviceKeys) == 0 {
+		return errors.New("missing bark device key")
+	}
+	switch c.Level {
+	case "", BarkLevelActive, BarkLevelTimeSensitive, BarkLevelPassive:
+	default:
+		return fmt.Errorf("unsupported bark level %q", c.Level)
+	}
+	if c.IconURL != "" {
+		if err := validateHTTPURL(c.IconURL); err != nil {
+			return fmt.Errorf("invalid bark icon url: %w", err)
+		}
+	}
+	if c.ClickURL != "" {
+		if err := validateHTTPURL(c.ClickURL); err != nil {
+			return fmt.Errorf("invalid bark click url: %w", err)
+		}
+	}
+	if c.EncryptionKey != "" || c.EncryptionIV != "" {
+		switch len(c.EncryptionKey) {
+		case 16, 24, 32:
+		default:
+			return errors.New("bark encryption key must be 16, 24 or 32 bytes")
+		}
+		if len(c.EncryptionIV) != 16 {
+			return errors.New("bark encryption iv must be 16 bytes")
+		}
+	}
+	return nil
+}
+
+func decodeBarkConfig(data json.RawMessage) (BarkConfig, error) {
+	var cfg BarkConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing bark config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode bark config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// BarkConfig extracts the Bark configuration for the method.
+func (m Method) BarkConfig() (BarkConfig, error) {
+	if m.Type != MethodBark {
+		return BarkConfig{}, errors.New("notification method is not bark")
+	}
+	return decodeBarkConfig(m.Config)
+}
+
+// NewBarkMethod builds a Method entry for Bark configuration.
+func NewBarkMethod(cfg BarkConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode bark config: %w", err)
+	}
+	return Method{
+		Type:   MethodBark,
+		Config: data,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,8 +17,36 @@ var ErrNotConfigured = errors.New("notification configuration not found")
 type MethodType string
 
 const (
-	MethodTelegram MethodType = "telegram"
-	MethodOS       MethodType = "os"
+	MethodTelegram      MethodType = "telegram"
+	MethodOS            MethodType = "os"
+	MethodSlack         MethodType = "slack"
+	MethodDiscord       MethodType = "discord"
+	MethodWeCom         MethodType = "wecom"
+	MethodDingTalk      MethodType = "dingtalk"
+	MethodFeishu        MethodType = "feishu"
+	MethodBark          MethodType = "bark"
+	MethodNtfy          MethodType = "ntfy"
+	MethodGotify        MethodType = "gotify"
+	MethodPushover      MethodType = "pushover"
+	MethodEmail         MethodType = "email"
+	MethodWebhook       MethodType = "webhook"
+	MethodTeams         MethodType = "teams"
+	MethodMatrix        MethodType = "matrix"
+	MethodMattermost    MethodType = "mattermost"
+	MethodRocketChat    MethodType = "rocketchat"
+	MethodGoogleChat    MethodType = "googlechat"
+	MethodServerChan    MethodType = "serverchan"
+	MethodPushPlus      MethodType = "pushplus"
+	MethodTerminal      MethodType = "terminal"
+	MethodExec          MethodType = "exec"
+	MethodLog           MethodType = "log"
+	MethodSyslog        MethodType = "syslog"
+	MethodMQTT          MethodType = "mqtt"
+	MethodHomeAssistant MethodType = "homeassistant"
+	MethodPagerDuty     MethodType = "pagerduty"
+	MethodOpsgenie      MethodType = "opsgenie"
+	MethodTwilio        MethodType = "twilio"
+	MethodSignal        MethodType = "signal"
 
 	// defaultNotificationMessage 是通知内容的默认值。
 	defaultNotificationMessage = "即将进行汇报，请注意查看..."
@@ -86,6 +115,118 @@ func (m *Method) validate() error {
 		m.tgCache = &cfg
 	case MethodOS:
 
+	case MethodSlack:
+		if _, err := decodeSlackConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodDiscord:
+		if _, err := decodeDiscordConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodWeCom:
+		if _, err := decodeWeComConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodDingTalk:
+		if _, err := decodeDingTalkConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodFeishu:
+		if _, err := decodeFeishuConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodBark:
+		if _, err := decodeBarkConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodNtfy:
+		if _, err := decodeNtfyConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodGotify:
+		if _, err := decodeGotifyConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodPushover:
+		if _, err := decodePushoverConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodEmail:
+		if _, err := decodeEmailConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodWebhook:
+		if _, err := decodeWebhookConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodTeams:
+		if _, err := decodeTeamsConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodMatrix:
+		if _, err := decodeMatrixConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodMattermost:
+		if _, err := decodeMattermostConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodRocketChat:
+		if _, err := decodeRocketChatConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodGoogleChat:
+		if _, err := decodeGoogleChatConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodServerChan:
+		if _, err := decodeServerChanConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodPushPlus:
+		if _, err := decodePushPlusConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodTerminal:
+		if _, err := decodeTerminalConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodExec:
+		if _, err := decodeExecConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodLog:
+		if _, err := decodeLogConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodSyslog:
+		if _, err := decodeSyslogConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodMQTT:
+		if _, err := decodeMQTTConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodHomeAssistant:
+		if _, err := decodeHomeAssistantConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodPagerDuty:
+		if _, err := decodePagerDutyConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodOpsgenie:
+		if _, err := decodeOpsgenieConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodTwilio:
+		if _, err := decodeTwilioConfig(m.Config); err != nil {
+			return err
+		}
+	case MethodSignal:
+		if _, err := decodeSignalConfig(m.Config); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unsupported method type %q", m.Type)
 	}
@@ -140,6 +281,21 @@ func (c TelegramConfig) Validate() error {
 	return nil
 }
 
+// validateHTTPURL ensures the value is an absolute http(s) URL.
+func validateHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("missing host")
+	}
+	return nil
+}
+
 // Path returns the absolute path to the configuration file.
 func Path() (string, error) {
 	dir, err := os.UserConfigDir()
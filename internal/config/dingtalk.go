@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultDingTalkAPIBaseURL 是钉钉开放平台的官方地址。
+const DefaultDingTalkAPIBaseURL = "https://oapi.dingtalk.com"
+
+// DingTalkConfig holds the DingTalk custom robot configuration values.
+type DingTalkConfig struct {
+	APIBaseURL  string `json:"apiBaseUrl"`
+	AccessToken string `json:"accessToken"`
+	// Secret 对应机器人安全设置中的「加签」密钥，留空表示未启用加签。
+	Secret string `json:"secret,omitempty"`
+	// Keyword 对应安全设置中的「自定义关键词」，消息中缺少时会自动补充。
+	Keyword   string   `json:"keyword,omitempty"`
+	AtMobiles []string `json:"atMobiles,omitempty"`
+	AtAll     bool     `json:"atAll,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c DingTalkConfig) Validate() error {
+	if c.APIBaseURL == "" {
+		return errors.New("missing dingtalk api base url")
+	}
+	if err := validateHTTPURL(c.APIBaseURL); err != nil {
+		return fmt.Errorf("invalid dingtalk api base url: %w", err)
+	}
+	if c.AccessToken == "" {
+		return errors.New("missing dingtalk access token")
+	}
+	return nil
+}
+
+func decodeDingTalkConfig(data json.RawMessage) (DingTalkConfig, error) {
+	var cfg DingTalkConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing dingtalk config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode dingtalk config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// DingTalkConfig extracts the DingTalk configuration for the method.
+func (m Method) DingTalkConfig() (DingTalkConfig, error) {
+	if m.Type != MethodDingTalk {
+		return DingTalkConfig{}, errors.New("notification method is not dingtalk")
+	}
+	return decodeDingTalkConfig(m.Config)
+}
+
+// NewDingTalkMethod builds a Method entry for DingTalk configuration.
+func NewDingTalkMethod(cfg DingTalkConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode dingtalk config: %w", err)
+	}
+	return Method{
+		Type:   MethodDingTalk,
+		Config: data,
+	}, nil
+}
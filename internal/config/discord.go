@@ -0,0 +1,67 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DiscordConfig holds the Discord webhook configuration values.
+type DiscordConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	Username   string `json:"username,omitempty"`
+	AvatarURL  string `json:"avatarUrl,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c DiscordConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return errors.New("missing discord webhook url")
+	}
+	if err := validateHTTPURL(c.WebhookURL); err != nil {
+		return fmt.Errorf("invalid discord webhook url: %w", err)
+	}
+	if c.AvatarURL != "" {
+		if err := validateHTTPURL(c.AvatarURL); err != nil {
+			return fmt.Errorf("invalid discord avatar url: %w", err)
+		}
+	}
+	return nil
+}
+
+func decodeDiscordConfig(data json.RawMessage) (DiscordConfig, error) {
+	var cfg DiscordConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing discord config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode discord config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// DiscordConfig extracts the Discord configuration for the method.
+func (m Method) DiscordConfig() (DiscordConfig, error) {
+	if m.Type != MethodDiscord {
+		return DiscordConfig{}, errors.New("notification method is not discord")
+	}
+	return decodeDiscordConfig(m.Config)
+}
+
+// NewDiscordMethod builds a Method entry for Discord configuration.
+func NewDiscordMethod(cfg DiscordConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode discord config: %w", err)
+	}
+	return Method{
+		Type:   MethodDiscord,
+		Config: data,
+	}, nil
+}
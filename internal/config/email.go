@@ -0,0 +1,109 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+)
+
+// SMTP 连接的加密方式。
+const (
+	EmailSecurityStartTLS = "starttls"
+	EmailSecurityTLS      = "tls"
+	EmailSecurityNone     = "none"
+)
+
+// EmailConfig holds the SMTP email configuration values.
+type EmailConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Security string   `json:"security"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+	Cc       []string `json:"cc,omitempty"`
+}
+
+// DefaultEmailPort returns the default SMTP port for the connection security mode.
+func DefaultEmailPort(security string) int {
+	switch security {
+	case EmailSecurityTLS:
+		return 465
+	case EmailSecurityNone:
+		return 25
+	default:
+		return 587
+	}
+}
+
+// Validate ensures all required settings are present.
+func (c EmailConfig) Validate() error {
+	if c.Host == "" {
+		return errors.New("missing email smtp host")
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid email smtp port %d", c.Port)
+	}
+	switch c.Security {
+	case EmailSecurityStartTLS, EmailSecurityTLS, EmailSecurityNone:
+	default:
+		return fmt.Errorf("unsupported email security %q", c.Security)
+	}
+	if c.Password != "" && c.Username == "" {
+		return errors.New("missing email username")
+	}
+	if c.From == "" {
+		return errors.New("missing email from address")
+	}
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("invalid email from address: %w", err)
+	}
+	if len(c.To) == 0 {
+		return errors.New("missing email to address")
+	}
+	for _, addr := range append(append([]string(nil), c.To...), c.Cc...) {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("invalid email recipient %q: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+func decodeEmailConfig(data json.RawMessage) (EmailConfig, error) {
+	var cfg EmailConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing email config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode email config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// EmailConfig extracts the email configuration for the method.
+func (m Method) EmailConfig() (EmailConfig, error) {
+	if m.Type != MethodEmail {
+		return EmailConfig{}, errors.New("notification method is not email")
+	}
+	return decodeEmailConfig(m.Config)
+}
+
+// NewEmailMethod builds a Method entry for email configuration.
+func NewEmailMethod(cfg EmailConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode email config: %w", err)
+	}
+	return Method{
+		Type:   MethodEmail,
+		Config: data,
+	}, nil
+}
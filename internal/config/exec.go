@@ -0,0 +1,66 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultExecTimeoutSeconds 是未配置超时时命令允许运行的最长时间。
+const DefaultExecTimeoutSeconds = 30
+
+// ExecConfig holds the command execution notification settings.
+type ExecConfig struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	// TimeoutSeconds 为 0 时使用 DefaultExecTimeoutSeconds。
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c ExecConfig) Validate() error {
+	if c.Command == "" {
+		return errors.New("missing exec command")
+	}
+	if c.TimeoutSeconds < 0 {
+		return fmt.Errorf("invalid exec timeout %d", c.TimeoutSeconds)
+	}
+	return nil
+}
+
+func decodeExecConfig(data json.RawMessage) (ExecConfig, error) {
+	var cfg ExecConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing exec config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode exec config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// ExecConfig extracts the command execution configuration for the method.
+func (m Method) ExecConfig() (ExecConfig, error) {
+	if m.Type != MethodExec {
+		return ExecConfig{}, errors.New("notification method is not exec")
+	}
+	return decodeExecConfig(m.Config)
+}
+
+// NewExecMethod builds a Method entry for command execution configuration.
+func NewExecMethod(cfg ExecConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode exec config: %w", err)
+	}
+	return Method{
+		Type:   MethodExec,
+		Config: data,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// FeishuConfig holds the Feishu/Lark custom bot configuration values.
+type FeishuConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	// Secret 对应机器人安全设置中的「签名校验」密钥，留空表示未启用。
+	Secret string `json:"secret,omitempty"`
+	// LinkURL 非空时在卡片底部展示跳转按钮。
+	LinkURL string `json:"linkUrl,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c FeishuConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return errors.New("missing feishu webhook url")
+	}
+	if err := validateHTTPURL(c.WebhookURL); err != nil {
+		return fmt.Errorf("invalid feishu webhook url: %w", err)
+	}
+	if c.LinkURL != "" {
+		if err := validateHTTPURL(c.LinkURL); err != nil {
+			return fmt.Errorf("invalid feishu link url: %w", err)
+		}
+	}
+	return nil
+}
+
+func decodeFeishuConfig(data json.RawMessage) (FeishuConfig, error) {
+	var cfg FeishuConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing feishu config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode feishu config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// FeishuConfig extracts the Feishu configuration for the method.
+func (m Method) FeishuConfig() (FeishuConfig, error) {
+	if m.Type != MethodFeishu {
+		return FeishuConfig{}, errors.New("notification method is not feishu")
+	}
+	return decodeFeishuConfig(m.Config)
+}
+
+// NewFeishuMethod builds a Method entry for Feishu configuration.
+func NewFeishuMethod(cfg FeishuConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode feishu config: %w", err)
+	}
+	return Method{
+		Type:   MethodFeishu,
+		Config: data,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// GoogleChatConfig holds the Google Chat space webhook configuration values.
+type GoogleChatConfig struct {
+	// WebhookURL 为空间 Webhook 的完整地址，已包含 key 与 token 参数。
+	WebhookURL string `json:"webhookUrl"`
+}
+
+// Validate ensures all required settings are present.
+func (c GoogleChatConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return errors.New("missing googlechat webhook url")
+	}
+	if err := validateHTTPURL(c.WebhookURL); err != nil {
+		return fmt.Errorf("invalid googlechat webhook url: %w", err)
+	}
+	return nil
+}
+
+func decodeGoogleChatConfig(data json.RawMessage) (GoogleChatConfig, error) {
+	var cfg GoogleChatConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing googlechat config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode googlechat config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// GoogleChatConfig extracts the GoogleChat configuration for the method.
+func (m Method) GoogleChatConfig() (GoogleChatConfig, error) {
+	if m.Type != MethodGoogleChat {
+		return GoogleChatConfig{}, errors.New("notification method is not googlechat")
+	}
+	return decodeGoogleChatConfig(m.Config)
+}
+
+// NewGoogleChatMethod builds a Method entry for GoogleChat configuration.
+func NewGoogleChatMethod(cfg GoogleChatConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode googlechat config: %w", err)
+	}
+	return Method{
+		Type:   MethodGoogleChat,
+		Config: data,
+	}, nil
+}
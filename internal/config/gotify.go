@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// GotifyConfig holds the Gotify server configuration values.
+type GotifyConfig struct {
+	ServerURL string `json:"serverUrl"`
+	Token     string `json:"token"`
+	// Priority 为 nil 时使用应用的默认优先级；0 表示静默，需要与未配置区分。
+	Priority *int `json:"priority,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c GotifyConfig) Validate() error {
+	if c.ServerURL == "" {
+		return errors.New("missing gotify server url")
+	}
+	if err := validateHTTPURL(c.ServerURL); err != nil {
+		return fmt.Errorf("invalid gotify server url: %w", err)
+	}
+	if c.Token == "" {
+		return errors.New("missing gotify app token")
+	}
+	if c.Priority != nil && (*c.Priority < 0 || *c.Priority > 10) {
+		return fmt.Errorf("gotify priority must be between 0 and 10, got %d", *c.Priority)
+	}
+	return nil
+}
+
+func decodeGotifyConfig(data json.RawMessage) (GotifyConfig, error) {
+	var cfg GotifyConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing gotify config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode gotify config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// GotifyConfig extracts the Gotify configuration for the method.
+func (m Method) GotifyConfig() (GotifyConfig, error) {
+	if m.Type != MethodGotify {
+		return GotifyConfig{}, errors.New("notification method is not gotify")
+	}
+	return decodeGotifyConfig(m.Config)
+}
+
+// NewGotifyMethod builds a Method entry for Gotify configuration.
+func NewGotifyMethod(cfg GotifyConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode gotify config: %w", err)
+	}
+	return Method{
+		Type:   MethodGotify,
+		Config: data,
+	}, nil
+}
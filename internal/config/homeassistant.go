@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Home Assistant 伴侣应用支持的推送优先级。
+const (
+	HomeAssistantPriorityNormal = "normal"
+	HomeAssistantPriorityHigh   = "high"
+)
+
+// HomeAssistantAction describes a URI action button in a companion app notification.
+type HomeAssistantAction struct {
+	Title string `json:"title"`
+	URI   string `json:"uri"`
+}
+
+// HomeAssistantConfig holds the Home Assistant notify service configuration values.
+type HomeAssistantConfig struct {
+	ServerURL string `json:"serverUrl"`
+	// Token 为用户资料页中创建的长期访问令牌。
+	Token string `json:"token"`
+	// Service 为 notify 域下的服务名，例如 mobile_app_pixel_8。
+	Service  string `json:"service"`
+	Priority string `json:"priority,omitempty"`
+	// Tag 非空时，相同 tag 的新通知会替换手机上的旧通知。
+	Tag     string                `json:"tag,omitempty"`
+	Actions []HomeAssistantAction `json:"actions,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c HomeAssistantConfig) Validate() error {
+	if c.ServerURL == "" {
+		return errors.New("missing homeassistant server url")
+	}
+	if err := validateHTTPURL(c.ServerURL); err != nil {
+		return fmt.Errorf("invalid homeassistant server url: %w", err)
+	}
+	if c.Token == "" {
+		return errors.New("missing homeassistant access token")
+	}
+	if c.Service == "" {
+		return errors.New("missing homeassistant notify service")
+	}
+	if strings.ContainsAny(c.Service, "/.") {
+		return fmt.Errorf("invalid homeassistant notify service %q", c.Service)
+	}
+	switch c.Priority {
+	case "", HomeAssistantPriorityNormal, HomeAssistantPriorityHigh:
+	default:
+		return fmt.Errorf("unsupported homeassistant priority %q", c.Priority)
+	}
+	for _, action := range c.Actions {
+		if action.Title == "" || action.URI == "" {
+			return errors.New("homeassistant action requires title and uri")
+		}
+	}
+	return nil
+}
+
+func decodeHomeAssistantConfig(data json.RawMessage) (HomeAssistantConfig, error) {
+	var cfg HomeAssistantConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing homeassistant config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode homeassistant config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// HomeAssistantConfig extracts the Home Assistant configuration for the method.
+func (m Method) HomeAssistantConfig() (HomeAssistantConfig, error) {
+	if m.Type != MethodHomeAssistant {
+		return HomeAssistantConfig{}, errors.New("notification method is not homeassistant")
+	}
+	return decodeHomeAssistantConfig(m.Config)
+}
+
+// NewHomeAssistantMethod builds a Method entry for Home Assistant configuration.
+func NewHomeAssistantMethod(cfg HomeAssistantConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode homeassistant config: %w", err)
+	}
+	return Method{
+		Type:   MethodHomeAssistant,
+		Config: data,
+	}, nil
+}
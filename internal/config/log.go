@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// 日志文件默认的轮转参数。
+const (
+	DefaultLogMaxSizeMB  = 10
+	DefaultLogMaxBackups = 3
+)
+
+// LogConfig holds the local JSONL audit log settings.
+type LogConfig struct {
+	Path string `json:"path"`
+	// MaxSizeMB 为单个文件的大小上限，超过后轮转为 path.1、path.2 ...；为 0 时不轮转。
+	MaxSizeMB int `json:"maxSizeMb,omitempty"`
+	// MaxBackups 为保留的历史文件数量，开启轮转时至少为 1，避免轮转时丢弃当前日志。
+	MaxBackups int `json:"maxBackups,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c LogConfig) Validate() error {
+	if c.Path == "" {
+		return errors.New("missing log file path")
+	}
+	if !filepath.IsAbs(c.Path) {
+		return fmt.Errorf("log file path %q must be absolute", c.Path)
+	}
+	if c.MaxSizeMB < 0 {
+		return fmt.Errorf("invalid log max size %d", c.MaxSizeMB)
+	}
+	if c.MaxBackups < 0 {
+		return fmt.Errorf("invalid log max backups %d", c.MaxBackups)
+	}
+	if c.MaxSizeMB > 0 && c.MaxBackups < 1 {
+		return errors.New("log max backups must be at least 1 when rotation is enabled")
+	}
+	return nil
+}
+
+func decodeLogConfig(data json.RawMessage) (LogConfig, error) {
+	var cfg LogConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing log config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode log config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// LogConfig extracts the JSONL log configuration for the method.
+func (m Method) LogConfig() (LogConfig, error) {
+	if m.Type != MethodLog {
+		return LogConfig{}, errors.New("notification method is not log")
+	}
+	return decodeLogConfig(m.Config)
+}
+
+// NewLogMethod builds a Method entry for JSONL log configuration.
+func NewLogMethod(cfg LogConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode log config: %w", err)
+	}
+	return Method{
+		Type:   MethodLog,
+		Config: data,
+	}, nil
+}
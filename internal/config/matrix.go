@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MatrixConfig holds the Matrix client-server API configuration values.
+type MatrixConfig struct {
+	HomeserverURL string `json:"homeserverUrl"`
+	AccessToken   string `json:"accessToken"`
+	RoomID        string `json:"roomId"`
+}
+
+// Validate ensures all required settings are present.
+func (c MatrixConfig) Validate() error {
+	if c.HomeserverURL == "" {
+		return errors.New("missing matrix homeserver url")
+	}
+	if err := validateHTTPURL(c.HomeserverURL); err != nil {
+		return fmt.Errorf("invalid matrix homeserver url: %w", err)
+	}
+	if c.AccessToken == "" {
+		return errors.New("missing matrix access token")
+	}
+	if c.RoomID == "" {
+		return errors.New("missing matrix room id")
+	}
+	// 发送消息接口只接受房间 ID（!xxx:server），不接受房间别名（#xxx:server）。
+	if !strings.HasPrefix(c.RoomID, "!") {
+		return fmt.Errorf("invalid matrix room id %q, expected !opaque:server", c.RoomID)
+	}
+	return nil
+}
+
+func decodeMatrixConfig(data json.RawMessage) (MatrixConfig, error) {
+	var cfg MatrixConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing matrix config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode matrix config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// MatrixConfig extracts the Matrix configuration for the method.
+func (m Method) MatrixConfig() (MatrixConfig, error) {
+	if m.Type != MethodMatrix {
+		return MatrixConfig{}, errors.New("notification method is not matrix")
+	}
+	return decodeMatrixConfig(m.Config)
+}
+
+// NewMatrixMethod builds a Method entry for Matrix configuration.
+func NewMatrixMethod(cfg MatrixConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode matrix config: %w", err)
+	}
+	return Method{
+		Type:   MethodMatrix,
+		Config: data,
+	}, nil
+}
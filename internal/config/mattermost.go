@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MattermostConfig holds the Mattermost incoming webhook configuration values.
+type MattermostConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	Channel    string `json:"channel,omitempty"`
+	Username   string `json:"username,omitempty"`
+	IconURL    string `json:"iconUrl,omitempty"`
+	IconEmoji  string `json:"iconEmoji,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c MattermostConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return errors.New("missing mattermost webhook url")
+	}
+	if err := validateHTTPURL(c.WebhookURL); err != nil {
+		return fmt.Errorf("invalid mattermost webhook url: %w", err)
+	}
+	if c.IconURL != "" {
+		if err := validateHTTPURL(c.IconURL); err != nil {
+			return fmt.Errorf("invalid mattermost icon url: %w", err)
+		}
+	}
+	return nil
+}
+
+func decodeMattermostConfig(data json.RawMessage) (MattermostConfig, error) {
+	var cfg MattermostConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing mattermost config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode mattermost config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// MattermostConfig extracts the Mattermost configuration for the method.
+func (m Method) MattermostConfig() (MattermostConfig, error) {
+	if m.Type != MethodMattermost {
+		return MattermostConfig{}, errors.New("notification method is not mattermost")
+	}
+	return decodeMattermostConfig(m.Config)
+}
+
+// NewMattermostMethod builds a Method entry for Mattermost configuration.
+func NewMattermostMethod(cfg MattermostConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode mattermost config: %w", err)
+	}
+	return Method{
+		Type:   MethodMattermost,
+		Config: data,
+	}, nil
+}
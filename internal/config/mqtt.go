@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"text/template"
+)
+
+// DefaultMQTTTopic 是未配置主题模板时使用的发布主题。
+const DefaultMQTTTopic = "notify-mcp/{{.Level}}"
+
+// MQTTConfig holds the MQTT broker publish settings.
+type MQTTConfig struct {
+	// BrokerURL 形如 mqtt://host:1883，使用 mqtts:// 时通过 TLS 连接。
+	BrokerURL string `json:"brokerUrl"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+	// ClientID 留空时每次连接随机生成。
+	ClientID string `json:"clientId,omitempty"`
+	// Topic 为 Go text/template 模板，可引用 .TaskName .Level .Host。
+	Topic  string `json:"topic"`
+	QoS    int    `json:"qos,omitempty"`
+	Retain bool   `json:"retain,omitempty"`
+	// CAFile 为校验自签名 broker 证书的 PEM 文件，留空时使用系统证书。
+	CAFile string `json:"caFile,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c MQTTConfig) Validate() error {
+	if c.BrokerURL == "" {
+		return errors.New("missing mqtt broker url")
+	}
+	u, err := url.Parse(c.BrokerURL)
+	if err != nil {
+		return fmt.Errorf("invalid mqtt broker url: %w", err)
+	}
+	switch u.Scheme {
+	case "mqtt", "tcp", "mqtts", "ssl", "tls":
+	default:
+		return fmt.Errorf("invalid mqtt broker url: unsupported scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return errors.New("invalid mqtt broker url: missing host")
+	}
+	// MQTT 3.1.1 不允许只设置密码而不设置用户名。
+	if c.Password != "" && c.Username == "" {
+		return errors.New("mqtt password requires a username")
+	}
+	if _, err := c.TopicTemplate(); err != nil {
+		return err
+	}
+	// 仅支持 QoS 0 / 1，QoS 2 的四次握手对单条通知没有意义。
+	if c.QoS < 0 || c.QoS > 1 {
+		return fmt.Errorf("unsupported mqtt qos %d: must be 0 or 1", c.QoS)
+	}
+	return nil
+}
+
+// UseTLS reports whether the broker URL requires a TLS connection.
+func (c MQTTConfig) UseTLS() bool {
+	u, err := url.Parse(c.BrokerURL)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "mqtts" || u.Scheme == "ssl" || u.Scheme == "tls"
+}
+
+// TopicTemplate parses the configured topic template.
+func (c MQTTConfig) TopicTemplate() (*template.Template, error) {
+	if c.Topic == "" {
+		return nil, errors.New("missing mqtt topic")
+	}
+	tmpl, err := template.New("mqtt").Parse(c.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("parse mqtt topic template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func decodeMQTTConfig(data json.RawMessage) (MQTTConfig, error) {
+	var cfg MQTTConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing mqtt config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode mqtt config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// MQTTConfig extracts the MQTT configuration for the method.
+func (m Method) MQTTConfig() (MQTTConfig, error) {
+	if m.Type != MethodMQTT {
+		return MQTTConfig{}, errors.New("notification method is not mqtt")
+	}
+	return decodeMQTTConfig(m.Config)
+}
+
+// NewMQTTMethod builds a Method entry for MQTT configuration.
+func NewMQTTMethod(cfg MQTTConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode mqtt config: %w", err)
+	}
+	return Method{
+		Type:   MethodMQTT,
+		Config: data,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultNtfyServerURL 是 ntfy 官方公共服务地址。
+const DefaultNtfyServerURL = "https://ntfy.sh"
+
+// NtfyAction describes a "view" action button attached to an ntfy message.
+type NtfyAction struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// NtfyConfig holds the ntfy publish configuration values.
+type NtfyConfig struct {
+	ServerURL string `json:"serverUrl"`
+	Topic     string `json:"topic"`
+	// Username/Password 与 Token 分别对应 Basic 与 Bearer 认证，二选一。
+	Username string       `json:"username,omitempty"`
+	Password string       `json:"password,omitempty"`
+	Token    string       `json:"token,omitempty"`
+	Priority int          `json:"priority,omitempty"`
+	Tags     []string     `json:"tags,omitempty"`
+	ClickURL string       `json:"clickUrl,omitempty"`
+	Actions  []NtfyAction `json:"actions,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c NtfyConfig) Validate() error {
+	if c.ServerURL == "" {
+		return errors.New("missing ntfy server url")
+	}
+	if err := validateHTTPURL(c.ServerURL); err != nil {
+		return fmt.Errorf("invalid ntfy server url: %w", err)
+	}
+	if c.Topic == "" {
+		return errors.New("missing ntfy topic")
+	}
+	if c.Token != "" && (c.Username != "" || c.Password != "") {
+		return errors.New("ntfy token and basic auth are mutually exclusive")
+	}
+	if c.Password != "" && c.Username == "" {
+		return errors.New("missing ntfy username")
+	}
+	if c.Priority < 0 || c.Priority > 5 {
+		return fmt.Errorf("ntfy priority must be between 1 and 5, got %d", c.Priority)
+	}
+	// ntfy 单条消息最多支持 3 个操作按钮。
+	if len(c.Actions) > 3 {
+		return errors.New("ntfy supports at most 3 actions")
+	}
+	for i, action := range c.Actions {
+		if action.Label == "" {
+			return fmt.Errorf("missing ntfy action[%d] label", i)
+		}
+		if err := validateHTTPURL(action.URL); err != nil {
+			return fmt.Errorf("invalid ntfy action[%d] url: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func decodeNtfyConfig(data json.RawMessage) (NtfyConfig, error) {
+	var cfg NtfyConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing ntfy config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode ntfy config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// NtfyConfig extracts the ntfy configuration for the method.
+func (m Method) NtfyConfig() (NtfyConfig, error) {
+	if m.Type != MethodNtfy {
+		return NtfyConfig{}, errors.New("notification method is not ntfy")
+	}
+	return decodeNtfyConfig(m.Config)
+}
+
+// NewNtfyMethod builds a Method entry for ntfy configuration.
+func NewNtfyMethod(cfg NtfyConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode ntfy config: %w", err)
+	}
+	return Method{
+		Type:   MethodNtfy,
+		Config: data,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Opsgenie 各区域的 API 地址。
+const (
+	OpsgenieAPIBaseURLUS = "https://api.opsgenie.com"
+	OpsgenieAPIBaseURLEU = "https://api.eu.opsgenie.com"
+)
+
+// OpsgenieResponder identifies a team, user, escalation or schedule to notify.
+type OpsgenieResponder struct {
+	Type string `json:"type"`
+	// Name 为团队、升级策略或排班的名称；Type 为 user 时为用户名（邮箱）。
+	Name string `json:"name"`
+}
+
+// OpsgenieConfig holds the Opsgenie Alert API configuration values.
+type OpsgenieConfig struct {
+	// APIBaseURL 按账号所在区域选择 OpsgenieAPIBaseURLUS 或 OpsgenieAPIBaseURLEU。
+	APIBaseURL string              `json:"apiBaseUrl"`
+	APIKey     string              `json:"apiKey"`
+	Responders []OpsgenieResponder `json:"responders,omitempty"`
+	// Priority 取值 P1-P5，为空时由 Opsgenie 使用默认的 P3。
+	Priority string `json:"priority,omitempty"`
+	// MinLevel 为创建告警的最低通知级别，为空时使用 DefaultAlertMinLevel。
+	MinLevel string `json:"minLevel,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c OpsgenieConfig) Validate() error {
+	if c.APIBaseURL == "" {
+		return errors.New("missing opsgenie api base url")
+	}
+	if err := validateHTTPURL(c.APIBaseURL); err != nil {
+		return fmt.Errorf("invalid opsgenie api base url: %w", err)
+	}
+	if c.APIKey == "" {
+		return errors.New("missing opsgenie api key")
+	}
+	for _, r := range c.Responders {
+		switch r.Type {
+		case "team", "user", "escalation", "schedule":
+		default:
+			return fmt.Errorf("unsupported opsgenie responder type %q", r.Type)
+		}
+		if r.Name == "" {
+			return errors.New("missing opsgenie responder name")
+		}
+	}
+	switch c.Priority {
+	case "", "P1", "P2", "P3", "P4", "P5":
+	default:
+		return fmt.Errorf("unsupported opsgenie priority %q", c.Priority)
+	}
+	if err := validateAlertMinLevel(c.MinLevel); err != nil {
+		return fmt.Errorf("invalid opsgenie min level: %w", err)
+	}
+	return nil
+}
+
+// TriggerLevel returns the lowest notification level that creates an alert.
+func (c OpsgenieConfig) TriggerLevel() string {
+	return alertMinLevel(c.MinLevel)
+}
+
+func decodeOpsgenieConfig(data json.RawMessage) (OpsgenieConfig, error) {
+	var cfg OpsgenieConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing opsgenie config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode opsgenie config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// OpsgenieConfig extracts the Opsgenie configuration for the method.
+func (m Method) OpsgenieConfig() (OpsgenieConfig, error) {
+	if m.Type != MethodOpsgenie {
+		return OpsgenieConfig{}, errors.New("notification method is not opsgenie")
+	}
+	return decodeOpsgenieConfig(m.Config)
+}
+
+// NewOpsgenieMethod builds a Method entry for Opsgenie configuration.
+func NewOpsgenieMethod(cfg OpsgenieConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode opsgenie config: %w", err)
+	}
+	return Method{
+		Type:   MethodOpsgenie,
+		Config: data,
+	}, nil
+}
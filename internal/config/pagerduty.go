@@ -0,0 +1,119 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultPagerDutyAPIBaseURL 是 PagerDuty Events API v2 的官方地址。
+const DefaultPagerDutyAPIBaseURL = "https://events.pagerduty.com"
+
+// PagerDuty 事件支持的严重级别。
+const (
+	PagerDutySeverityCritical = "critical"
+	PagerDutySeverityError    = "error"
+	PagerDutySeverityWarning  = "warning"
+	PagerDutySeverityInfo     = "info"
+)
+
+// DefaultAlertMinLevel 是 PagerDuty / Opsgenie 默认的最低告警级别，低于该级别的通知不会触发告警。
+const DefaultAlertMinLevel = "error"
+
+// PagerDutyConfig holds the PagerDuty Events API v2 configuration values.
+type PagerDutyConfig struct {
+	APIBaseURL string `json:"apiBaseUrl"`
+	// RoutingKey 为服务集成（Events API v2）中的 Integration Key。
+	RoutingKey string `json:"routingKey"`
+	// Severities 覆盖通知级别到事件严重级别的默认映射，success 级别固定用于恢复事件。
+	Severities map[string]string `json:"severities,omitempty"`
+	// MinLevel 为触发事件的最低通知级别，为空时使用 DefaultAlertMinLevel。
+	MinLevel string `json:"minLevel,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c PagerDutyConfig) Validate() error {
+	if c.APIBaseURL == "" {
+		return errors.New("missing pagerduty api base url")
+	}
+	if err := validateHTTPURL(c.APIBaseURL); err != nil {
+		return fmt.Errorf("invalid pagerduty api base url: %w", err)
+	}
+	if c.RoutingKey == "" {
+		return errors.New("missing pagerduty routing key")
+	}
+	for level, severity := range c.Severities {
+		switch level {
+		case "info", "warning", "error", "critical":
+		default:
+			return fmt.Errorf("unsupported pagerduty severity mapping for level %q", level)
+		}
+		switch severity {
+		case PagerDutySeverityCritical, PagerDutySeverityError, PagerDutySeverityWarning, PagerDutySeverityInfo:
+		default:
+			return fmt.Errorf("unsupported pagerduty severity %q", severity)
+		}
+	}
+	if err := validateAlertMinLevel(c.MinLevel); err != nil {
+		return fmt.Errorf("invalid pagerduty min level: %w", err)
+	}
+	return nil
+}
+
+// TriggerLevel returns the lowest notification level that triggers an incident.
+func (c PagerDutyConfig) TriggerLevel() string {
+	return alertMinLevel(c.MinLevel)
+}
+
+func validateAlertMinLevel(level string) error {
+	switch level {
+	case "", "info", "warning", "error", "critical":
+		return nil
+	default:
+		return fmt.Errorf("unsupported level %q", level)
+	}
+}
+
+func alertMinLevel(level string) string {
+	if level == "" {
+		return DefaultAlertMinLevel
+	}
+	return level
+}
+
+func decodePagerDutyConfig(data json.RawMessage) (PagerDutyConfig, error) {
+	var cfg PagerDutyConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing pagerduty config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode pagerduty config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// PagerDutyConfig extracts the PagerDuty configuration for the method.
+func (m Method) PagerDutyConfig() (PagerDutyConfig, error) {
+	if m.Type != MethodPagerDuty {
+		return PagerDutyConfig{}, errors.New("notification method is not pagerduty")
+	}
+	return decodePagerDutyConfig(m.Config)
+}
+
+// NewPagerDutyMethod builds a Method entry for PagerDuty configuration.
+func NewPagerDutyMethod(cfg PagerDutyConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode pagerduty config: %w", err)
+	}
+	return Method{
+		Type:   MethodPagerDuty,
+		Config: data,
+	}, nil
+}
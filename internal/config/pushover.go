@@ -0,0 +1,91 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultPushoverAPIBaseURL 是 Pushover 官方 API 地址。
+const DefaultPushoverAPIBaseURL = "https://api.pushover.net"
+
+// PushoverPriorityEmergency 为紧急优先级，消息会反复提醒直到用户确认。
+const PushoverPriorityEmergency = 2
+
+// PushoverConfig holds the Pushover application configuration values.
+type PushoverConfig struct {
+	APIBaseURL string `json:"apiBaseUrl"`
+	Token      string `json:"token"`
+	UserKey    string `json:"userKey"`
+	Device     string `json:"device,omitempty"`
+	Sound      string `json:"sound,omitempty"`
+	Priority   int    `json:"priority,omitempty"`
+	// Retry 与 Expire 仅在紧急优先级下生效，单位为秒。
+	Retry  int `json:"retry,omitempty"`
+	Expire int `json:"expire,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c PushoverConfig) Validate() error {
+	if c.APIBaseURL == "" {
+		return errors.New("missing pushover api base url")
+	}
+	if err := validateHTTPURL(c.APIBaseURL); err != nil {
+		return fmt.Errorf("invalid pushover api base url: %w", err)
+	}
+	if c.Token == "" {
+		return errors.New("missing pushover app token")
+	}
+	if c.UserKey == "" {
+		return errors.New("missing pushover user key")
+	}
+	if c.Priority < -2 || c.Priority > PushoverPriorityEmergency {
+		return fmt.Errorf("pushover priority must be between -2 and 2, got %d", c.Priority)
+	}
+	if c.Priority == PushoverPriorityEmergency {
+		if c.Retry < 30 {
+			return errors.New("pushover retry must be at least 30 seconds for emergency priority")
+		}
+		if c.Expire <= 0 || c.Expire > 10800 {
+			return errors.New("pushover expire must be between 1 and 10800 seconds for emergency priority")
+		}
+	}
+	return nil
+}
+
+func decodePushoverConfig(data json.RawMessage) (PushoverConfig, error) {
+	var cfg PushoverConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing pushover config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode pushover config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// PushoverConfig extracts the Pushover configuration for the method.
+func (m Method) PushoverConfig() (PushoverConfig, error) {
+	if m.Type != MethodPushover {
+		return PushoverConfig{}, errors.New("notification method is not pushover")
+	}
+	return decodePushoverConfig(m.Config)
+}
+
+// NewPushoverMethod builds a Method entry for Pushover configuration.
+func NewPushoverMethod(cfg PushoverConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode pushover config: %w", err)
+	}
+	return Method{
+		Type:   MethodPushover,
+		Config: data,
+	}, nil
+}
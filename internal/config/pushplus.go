@@ -0,0 +1,81 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultPushPlusAPIBaseURL 是 PushPlus 的官方接口地址。
+const DefaultPushPlusAPIBaseURL = "https://www.pushplus.plus"
+
+// PushPlus 支持的消息模板。
+const (
+	PushPlusTemplateHTML     = "html"
+	PushPlusTemplateMarkdown = "markdown"
+)
+
+// PushPlusConfig holds the PushPlus push configuration values.
+type PushPlusConfig struct {
+	APIBaseURL string `json:"apiBaseUrl"`
+	Token      string `json:"token"`
+	// Topic 为群组编码，非空时一对多推送给群组内所有订阅者。
+	Topic    string `json:"topic,omitempty"`
+	Template string `json:"template"`
+}
+
+// Validate ensures all required settings are present.
+func (c PushPlusConfig) Validate() error {
+	if c.APIBaseURL == "" {
+		return errors.New("missing pushplus api base url")
+	}
+	if err := validateHTTPURL(c.APIBaseURL); err != nil {
+		return fmt.Errorf("invalid pushplus api base url: %w", err)
+	}
+	if c.Token == "" {
+		return errors.New("missing pushplus token")
+	}
+	switch c.Template {
+	case PushPlusTemplateHTML, PushPlusTemplateMarkdown:
+	default:
+		return fmt.Errorf("unsupported pushplus template %q", c.Template)
+	}
+	return nil
+}
+
+func decodePushPlusConfig(data json.RawMessage) (PushPlusConfig, error) {
+	var cfg PushPlusConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing pushplus config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode pushplus config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// PushPlusConfig extracts the PushPlus configuration for the method.
+func (m Method) PushPlusConfig() (PushPlusConfig, error) {
+	if m.Type != MethodPushPlus {
+		return PushPlusConfig{}, errors.New("notification method is not pushplus")
+	}
+	return decodePushPlusConfig(m.Config)
+}
+
+// NewPushPlusMethod builds a Method entry for PushPlus configuration.
+func NewPushPlusMethod(cfg PushPlusConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode pushplus config: %w", err)
+	}
+	return Method{
+		Type:   MethodPushPlus,
+		Config: data,
+	}, nil
+}
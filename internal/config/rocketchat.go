@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// RocketChatConfig holds the Rocket.Chat incoming webhook configuration values.
+type RocketChatConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	Channel    string `json:"channel,omitempty"`
+	Username   string `json:"username,omitempty"`
+	IconURL    string `json:"iconUrl,omitempty"`
+	IconEmoji  string `json:"iconEmoji,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c RocketChatConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return errors.New("missing rocketchat webhook url")
+	}
+	if err := validateHTTPURL(c.WebhookURL); err != nil {
+		return fmt.Errorf("invalid rocketchat webhook url: %w", err)
+	}
+	if c.IconURL != "" {
+		if err := validateHTTPURL(c.IconURL); err != nil {
+			return fmt.Errorf("invalid rocketchat icon url: %w", err)
+		}
+	}
+	return nil
+}
+
+func decodeRocketChatConfig(data json.RawMessage) (RocketChatConfig, error) {
+	var cfg RocketChatConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing rocketchat config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode rocketchat config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// RocketChatConfig extracts the RocketChat configuration for the method.
+func (m Method) RocketChatConfig() (RocketChatConfig, error) {
+	if m.Type != MethodRocketChat {
+		return RocketChatConfig{}, errors.New("notification method is not rocketchat")
+	}
+	return decodeRocketChatConfig(m.Config)
+}
+
+// NewRocketChatMethod builds a Method entry for RocketChat configuration.
+func NewRocketChatMethod(cfg RocketChatConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode rocketchat config: %w", err)
+	}
+	return Method{
+		Type:   MethodRocketChat,
+		Config: data,
+	}, nil
+}
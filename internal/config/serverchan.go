@@ -0,0 +1,65 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ServerChanConfig holds the ServerChan (Server酱) push configuration values.
+type ServerChanConfig struct {
+	// SendKey 支持 Turbo 版（SCT 开头）与 Server酱³（sctp 开头），发送时据此选择接口。
+	SendKey string `json:"sendKey"`
+	// APIBaseURL 非空时覆盖根据 SendKey 推导出的接口地址。
+	APIBaseURL string `json:"apiBaseUrl,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c ServerChanConfig) Validate() error {
+	if c.SendKey == "" {
+		return errors.New("missing serverchan send key")
+	}
+	if c.APIBaseURL != "" {
+		if err := validateHTTPURL(c.APIBaseURL); err != nil {
+			return fmt.Errorf("invalid serverchan api base url: %w", err)
+		}
+	}
+	return nil
+}
+
+func decodeServerChanConfig(data json.RawMessage) (ServerChanConfig, error) {
+	var cfg ServerChanConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing serverchan config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode serverchan config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// ServerChanConfig extracts the ServerChan configuration for the method.
+func (m Method) ServerChanConfig() (ServerChanConfig, error) {
+	if m.Type != MethodServerChan {
+		return ServerChanConfig{}, errors.New("notification method is not serverchan")
+	}
+	return decodeServerChanConfig(m.Config)
+}
+
+// NewServerChanMethod builds a Method entry for ServerChan configuration.
+func NewServerChanMethod(cfg ServerChanConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode serverchan config: %w", err)
+	}
+	return Method{
+		Type:   MethodServerChan,
+		Config: data,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// signalGroupPrefix 是 signal-cli-rest-api 中群组 ID 的前缀。
+const signalGroupPrefix = "group."
+
+// SignalConfig holds the signal-cli-rest-api configuration values.
+type SignalConfig struct {
+	ServerURL string `json:"serverUrl"`
+	// Number 为已在 signal-cli 中注册或关联的发送号码。
+	Number     string   `json:"number"`
+	Recipients []string `json:"recipients,omitempty"`
+	// GroupIDs 为 GET /v1/groups/{number} 返回的 id 字段，形如 group.xxxx。
+	GroupIDs []string `json:"groupIds,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c SignalConfig) Validate() error {
+	if c.ServerURL == "" {
+		return errors.New("missing signal server url")
+	}
+	if err := validateHTTPURL(c.ServerURL); err != nil {
+		return fmt.Errorf("invalid signal server url: %w", err)
+	}
+	if c.Number == "" {
+		return errors.New("missing signal number")
+	}
+	if !e164Pattern.MatchString(c.Number) {
+		return fmt.Errorf("invalid signal number %q: must be in E.164 format", c.Number)
+	}
+	if len(c.Recipients) == 0 && len(c.GroupIDs) == 0 {
+		return errors.New("missing signal recipients or group ids")
+	}
+	for _, id := range c.GroupIDs {
+		if !strings.HasPrefix(id, signalGroupPrefix) {
+			return fmt.Errorf("invalid signal group id %q: must start with %q", id, signalGroupPrefix)
+		}
+	}
+	return nil
+}
+
+func decodeSignalConfig(data json.RawMessage) (SignalConfig, error) {
+	var cfg SignalConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing signal config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode signal config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// SignalConfig extracts the Signal configuration for the method.
+func (m Method) SignalConfig() (SignalConfig, error) {
+	if m.Type != MethodSignal {
+		return SignalConfig{}, errors.New("notification method is not signal")
+	}
+	return decodeSignalConfig(m.Config)
+}
+
+// NewSignalMethod builds a Method entry for Signal configuration.
+func NewSignalMethod(cfg SignalConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode signal config: %w", err)
+	}
+	return Method{
+		Type:   MethodSignal,
+		Config: data,
+	}, nil
+}
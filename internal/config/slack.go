@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SlackConfig holds the Slack incoming webhook configuration values.
+type SlackConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	Channel    string `json:"channel,omitempty"`
+	Username   string `json:"username,omitempty"`
+	IconEmoji  string `json:"iconEmoji,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c SlackConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return errors.New("missing slack webhook url")
+	}
+	if err := validateHTTPURL(c.WebhookURL); err != nil {
+		return fmt.Errorf("invalid slack webhook url: %w", err)
+	}
+	return nil
+}
+
+func decodeSlackConfig(data json.RawMessage) (SlackConfig, error) {
+	var cfg SlackConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing slack config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode slack config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// SlackConfig extracts the Slack configuration for the method.
+func (m Method) SlackConfig() (SlackConfig, error) {
+	if m.Type != MethodSlack {
+		return SlackConfig{}, errors.New("notification method is not slack")
+	}
+	return decodeSlackConfig(m.Config)
+}
+
+// NewSlackMethod builds a Method entry for Slack configuration.
+func NewSlackMethod(cfg SlackConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode slack config: %w", err)
+	}
+	return Method{
+		Type:   MethodSlack,
+		Config: data,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultSyslogIdent 是写入系统日志时默认使用的标识。
+const DefaultSyslogIdent = "notify-mcp"
+
+// 系统日志的写入后端。
+const (
+	// SyslogBackendAuto 在 journald 套接字存在时使用 journald，否则使用 syslog。
+	SyslogBackendAuto     = "auto"
+	SyslogBackendSyslog   = "syslog"
+	SyslogBackendJournald = "journald"
+)
+
+// SyslogConfig holds the local syslog / journald settings.
+type SyslogConfig struct {
+	Backend string `json:"backend"`
+	Ident   string `json:"ident"`
+}
+
+// Validate ensures all required settings are present.
+func (c SyslogConfig) Validate() error {
+	switch c.Backend {
+	case SyslogBackendAuto, SyslogBackendSyslog, SyslogBackendJournald:
+	default:
+		return fmt.Errorf("unsupported syslog backend %q", c.Backend)
+	}
+	if c.Ident == "" {
+		return errors.New("missing syslog ident")
+	}
+	return nil
+}
+
+func decodeSyslogConfig(data json.RawMessage) (SyslogConfig, error) {
+	var cfg SyslogConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing syslog config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode syslog config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// SyslogConfig extracts the syslog configuration for the method.
+func (m Method) SyslogConfig() (SyslogConfig, error) {
+	if m.Type != MethodSyslog {
+		return SyslogConfig{}, errors.New("notification method is not syslog")
+	}
+	return decodeSyslogConfig(m.Config)
+}
+
+// NewSyslogMethod builds a Method entry for syslog configuration.
+func NewSyslogMethod(cfg SyslogConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode syslog config: %w", err)
+	}
+	return Method{
+		Type:   MethodSyslog,
+		Config: data,
+	}, nil
+}
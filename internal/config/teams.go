@@ -0,0 +1,68 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// TeamsConfig holds the Microsoft Teams webhook configuration values.
+type TeamsConfig struct {
+	// WebhookURL 可以是传统的 Incoming Webhook 地址，也可以是 Workflows（Power Automate）触发地址。
+	WebhookURL string `json:"webhookUrl"`
+	// LinkURL 非空时在卡片底部展示 "Open" 按钮。
+	LinkURL string `json:"linkUrl,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c TeamsConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return errors.New("missing teams webhook url")
+	}
+	if err := validateHTTPURL(c.WebhookURL); err != nil {
+		return fmt.Errorf("invalid teams webhook url: %w", err)
+	}
+	if c.LinkURL != "" {
+		if err := validateHTTPURL(c.LinkURL); err != nil {
+			return fmt.Errorf("invalid teams link url: %w", err)
+		}
+	}
+	return nil
+}
+
+func decodeTeamsConfig(data json.RawMessage) (TeamsConfig, error) {
+	var cfg TeamsConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing teams config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode teams config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// TeamsConfig extracts the Teams configuration for the method.
+func (m Method) TeamsConfig() (TeamsConfig, error) {
+	if m.Type != MethodTeams {
+		return TeamsConfig{}, errors.New("notification method is not teams")
+	}
+	return decodeTeamsConfig(m.Config)
+}
+
+// NewTeamsMethod builds a Method entry for Teams configuration.
+func NewTeamsMethod(cfg TeamsConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode teams config: %w", err)
+	}
+	return Method{
+		Type:   MethodTeams,
+		Config: data,
+	}, nil
+}
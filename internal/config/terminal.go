@@ -0,0 +1,86 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// 终端通知支持的转义序列协议。
+const (
+	// TerminalProtocolOSC9 适用于 iTerm2、WezTerm、Windows Terminal 等。
+	TerminalProtocolOSC9 = "osc9"
+	// TerminalProtocolOSC777 适用于 urxvt、foot、Ghostty 等。
+	TerminalProtocolOSC777 = "osc777"
+	// TerminalProtocolOSC99 适用于 kitty。
+	TerminalProtocolOSC99 = "osc99"
+)
+
+// 终端复用器的透传方式。
+const (
+	// TerminalPassthroughAuto 根据 TMUX / STY 环境变量自动判断。
+	TerminalPassthroughAuto   = "auto"
+	TerminalPassthroughTmux   = "tmux"
+	TerminalPassthroughScreen = "screen"
+	TerminalPassthroughNone   = "none"
+)
+
+// TerminalConfig holds the terminal escape-sequence notification settings.
+type TerminalConfig struct {
+	Protocol    string `json:"protocol"`
+	Passthrough string `json:"passthrough"`
+	// TTY 为写入的终端设备，留空时使用当前进程的控制终端。
+	TTY string `json:"tty,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c TerminalConfig) Validate() error {
+	switch c.Protocol {
+	case TerminalProtocolOSC9, TerminalProtocolOSC777, TerminalProtocolOSC99:
+	default:
+		return fmt.Errorf("unsupported terminal protocol %q", c.Protocol)
+	}
+	switch c.Passthrough {
+	case TerminalPassthroughAuto, TerminalPassthroughTmux, TerminalPassthroughScreen, TerminalPassthroughNone:
+	default:
+		return fmt.Errorf("unsupported terminal passthrough %q", c.Passthrough)
+	}
+	return nil
+}
+
+func decodeTerminalConfig(data json.RawMessage) (TerminalConfig, error) {
+	var cfg TerminalConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing terminal config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode terminal config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// TerminalConfig extracts the terminal configuration for the method.
+func (m Method) TerminalConfig() (TerminalConfig, error) {
+	if m.Type != MethodTerminal {
+		return TerminalConfig{}, errors.New("notification method is not terminal")
+	}
+	return decodeTerminalConfig(m.Config)
+}
+
+// NewTerminalMethod builds a Method entry for terminal configuration.
+func NewTerminalMethod(cfg TerminalConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode terminal config: %w", err)
+	}
+	return Method{
+		Type:   MethodTerminal,
+		Config: data,
+	}, nil
+}
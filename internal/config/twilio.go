@@ -0,0 +1,92 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// DefaultTwilioAPIBaseURL 是 Twilio REST API 的官方地址。
+const DefaultTwilioAPIBaseURL = "https://api.twilio.com"
+
+// e164Pattern 匹配 E.164 格式的电话号码，例如 +8613800138000。
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// TwilioConfig holds the Twilio SMS and voice call configuration values.
+type TwilioConfig struct {
+	APIBaseURL string   `json:"apiBaseUrl"`
+	AccountSID string   `json:"accountSid"`
+	AuthToken  string   `json:"authToken"`
+	From       string   `json:"from"`
+	To         []string `json:"to"`
+	// VoiceOnCritical 为 true 时，critical 级别的通知会在短信之外额外拨打语音电话。
+	VoiceOnCritical bool `json:"voiceOnCritical,omitempty"`
+	// VoiceLanguage 为语音播报使用的语言，例如 cmn-CN，为空时使用 Twilio 默认语言。
+	VoiceLanguage string `json:"voiceLanguage,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c TwilioConfig) Validate() error {
+	if c.APIBaseURL == "" {
+		return errors.New("missing twilio api base url")
+	}
+	if err := validateHTTPURL(c.APIBaseURL); err != nil {
+		return fmt.Errorf("invalid twilio api base url: %w", err)
+	}
+	if c.AccountSID == "" {
+		return errors.New("missing twilio account sid")
+	}
+	if c.AuthToken == "" {
+		return errors.New("missing twilio auth token")
+	}
+	if c.From == "" {
+		return errors.New("missing twilio from number")
+	}
+	if len(c.To) == 0 {
+		return errors.New("missing twilio to number")
+	}
+	for _, number := range c.To {
+		if !e164Pattern.MatchString(number) {
+			return fmt.Errorf("invalid twilio to number %q: must be in E.164 format", number)
+		}
+	}
+	return nil
+}
+
+func decodeTwilioConfig(data json.RawMessage) (TwilioConfig, error) {
+	var cfg TwilioConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing twilio config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode twilio config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// TwilioConfig extracts the Twilio configuration for the method.
+func (m Method) TwilioConfig() (TwilioConfig, error) {
+	if m.Type != MethodTwilio {
+		return TwilioConfig{}, errors.New("notification method is not twilio")
+	}
+	return decodeTwilioConfig(m.Config)
+}
+
+// NewTwilioMethod builds a Method entry for Twilio configuration.
+func NewTwilioMethod(cfg TwilioConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode twilio config: %w", err)
+	}
+	return Method{
+		Type:   MethodTwilio,
+		Config: data,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+)
+
+const (
+	// DefaultWebhookBodyTemplate 是未配置请求体模板时使用的 JSON 模板。
+	DefaultWebhookBodyTemplate = `{"taskName":{{json .TaskName}},"message":{{json .Message}},"timestamp":{{json .Timestamp}},"host":{{json .Host}},"level":{{json .Level}}}`
+
+	// DefaultWebhookSignatureHeader 是 HMAC 签名默认写入的请求头。
+	DefaultWebhookSignatureHeader = "X-Notify-Signature"
+)
+
+// WebhookTemplateFuncs are the helper functions available in webhook body templates.
+var WebhookTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// WebhookTemplateData holds the fields available to webhook body templates.
+type WebhookTemplateData struct {
+	TaskName  string
+	Message   string
+	Timestamp string
+	Time      string
+	Host      string
+	Level     string
+	Text      string
+}
+
+// sampleWebhookData 用于在校验时试执行模板，提前发现引用了不存在字段等错误。
+var sampleWebhookData = WebhookTemplateData{
+	TaskName:  "task",
+	Message:   "message",
+	Timestamp: "2006-01-02T15:04:05Z",
+	Time:      "2006-01-02 15:04:05",
+	Host:      "localhost",
+	Level:     "info",
+	Text:      "text",
+}
+
+// WebhookConfig holds the generic HTTP webhook configuration values.
+type WebhookConfig struct {
+	URL string `json:"url"`
+	// Method 为 GET 时不发送请求体，通知字段以查询参数传递，此时不能配置 BodyTemplate。
+	Method       string            `json:"method"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	BodyTemplate string            `json:"bodyTemplate,omitempty"`
+	// SuccessStatusCodes 为空时任意 2xx 状态码均视为成功。
+	SuccessStatusCodes []int `json:"successStatusCodes,omitempty"`
+	// SignatureSecret 非空时使用 HMAC-SHA256 对请求体（GET 请求为查询串）签名，写入 SignatureHeader。
+	SignatureSecret string `json:"signatureSecret,omitempty"`
+	SignatureHeader string `json:"signatureHeader,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c WebhookConfig) Validate() error {
+	if c.URL == "" {
+		return errors.New("missing webhook url")
+	}
+	if err := validateHTTPURL(c.URL); err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+	switch c.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("unsupported webhook http method %q", c.Method)
+	}
+	if c.Method == http.MethodGet && c.BodyTemplate != "" {
+		return errors.New("webhook body template is not supported with GET, fields are sent as query parameters")
+	}
+	tmpl, err := c.Template()
+	if err != nil {
+		return err
+	}
+	// 解析只能发现语法错误，试执行一次才能发现字段名拼写等错误。
+	if err := tmpl.Execute(io.Discard, sampleWebhookData); err != nil {
+		return fmt.Errorf("render webhook body template: %w", err)
+	}
+	for _, code := range c.SuccessStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid webhook success status code %d", code)
+		}
+	}
+	return nil
+}
+
+// Template parses the configured body template, falling back to DefaultWebhookBodyTemplate.
+func (c WebhookConfig) Template() (*template.Template, error) {
+	text := c.BodyTemplate
+	if text == "" {
+		text = DefaultWebhookBodyTemplate
+	}
+	tmpl, err := template.New("webhook").Funcs(WebhookTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook body template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func decodeWebhookConfig(data json.RawMessage) (WebhookConfig, error) {
+	var cfg WebhookConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing webhook config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode webhook config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// WebhookConfig extracts the webhook configuration for the method.
+func (m Method) WebhookConfig() (WebhookConfig, error) {
+	if m.Type != MethodWebhook {
+		return WebhookConfig{}, errors.New("notification method is not webhook")
+	}
+	return decodeWebhookConfig(m.Config)
+}
+
+// NewWebhookMethod builds a Method entry for webhook configuration.
+func NewWebhookMethod(cfg WebhookConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode webhook config: %w", err)
+	}
+	return Method{
+		Type:   MethodWebhook,
+		Config: data,
+	}, nil
+}
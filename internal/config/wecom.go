@@ -0,0 +1,94 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// DefaultWeComAPIBaseURL 是企业微信群机器人接口的官方地址。
+const DefaultWeComAPIBaseURL = "https://qyapi.weixin.qq.com"
+
+// WeCom 群机器人支持的消息类型。
+const (
+	WeComMsgTypeMarkdown = "markdown"
+	WeComMsgTypeText     = "text"
+)
+
+// WeComMentionAll 表示提醒群内所有人，仅 text 类型消息支持。
+const WeComMentionAll = "@all"
+
+// WeComConfig holds the WeCom group robot configuration values.
+type WeComConfig struct {
+	APIBaseURL          string   `json:"apiBaseUrl"`
+	Key                 string   `json:"key"`
+	MsgType             string   `json:"msgType"`
+	MentionedList       []string `json:"mentionedList,omitempty"`
+	MentionedMobileList []string `json:"mentionedMobileList,omitempty"`
+}
+
+// Validate ensures all required settings are present.
+func (c WeComConfig) Validate() error {
+	if c.APIBaseURL == "" {
+		return errors.New("missing wecom api base url")
+	}
+	if err := validateHTTPURL(c.APIBaseURL); err != nil {
+		return fmt.Errorf("invalid wecom api base url: %w", err)
+	}
+	if c.Key == "" {
+		return errors.New("missing wecom webhook key")
+	}
+	switch c.MsgType {
+	case WeComMsgTypeMarkdown:
+		// markdown 消息只能通过 <@userid> 提醒成员，不支持按手机号提醒。
+		if len(c.MentionedMobileList) > 0 {
+			return errors.New("wecom mentioned mobile list requires text msg type")
+		}
+		// markdown 消息无法通过 <@userid> 提醒所有人。
+		if slices.Contains(c.MentionedList, WeComMentionAll) {
+			return errors.New("wecom mention @all requires text msg type")
+		}
+	case WeComMsgTypeText:
+	default:
+		return fmt.Errorf("unsupported wecom msg type %q", c.MsgType)
+	}
+	return nil
+}
+
+func decodeWeComConfig(data json.RawMessage) (WeComConfig, error) {
+	var cfg WeComConfig
+	if len(data) == 0 {
+		return cfg, errors.New("missing wecom config")
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decode wecom config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// WeComConfig extracts the WeCom configuration for the method.
+func (m Method) WeComConfig() (WeComConfig, error) {
+	if m.Type != MethodWeCom {
+		return WeComConfig{}, errors.New("notification method is not wecom")
+	}
+	return decodeWeComConfig(m.Config)
+}
+
+// NewWeComMethod builds a Method entry for WeCom configuration.
+func NewWeComMethod(cfg WeComConfig) (Method, error) {
+	if err := cfg.Validate(); err != nil {
+		return Method{}, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return Method{}, fmt.Errorf("encode wecom config: %w", err)
+	}
+	return Method{
+		Type:   MethodWeCom,
+		Config: data,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package dingtalk
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type markdownContent struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+type atContent struct {
+	AtMobiles []string `json:"atMobiles,omitempty"`
+	IsAtAll   bool     `json:"isAtAll,omitempty"`
+}
+
+type payload struct {
+	MsgType  string          `json:"msgtype"`
+	Markdown markdownContent `json:"markdown"`
+	At       atContent       `json:"at"`
+}
+
+type response struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// SendMessage posts a markdown message to the configured DingTalk robot.
+func SendMessage(ctx context.Context, cfg config.DingTalkConfig, msg notify.Message) error {
+	endpoint, err := buildURL(cfg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode dingtalk payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build dingtalk request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call dingtalk: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("dingtalk responded with %s", resp.Status)
+	}
+
+	// 签名错误、关键词不匹配等均以 HTTP 200 + 非 0 errcode 返回。
+	var result response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode dingtalk response: %w", err)
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("dingtalk responded with errcode %d: %s", result.ErrCode, result.ErrMsg)
+	}
+	return nil
+}
+
+// buildURL 拼接请求地址，启用加签时附带当前时间戳与签名。
+func buildURL(cfg config.DingTalkConfig, now time.Time) (string, error) {
+	base := strings.TrimRight(cfg.APIBaseURL, "/")
+	u, err := url.Parse(base + "/robot/send")
+	if err != nil {
+		return "", fmt.Errorf("parse dingtalk url: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("access_token", cfg.AccessToken)
+	if cfg.Secret != "" {
+		timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+		query.Set("timestamp", timestamp)
+		query.Set("sign", sign(timestamp, cfg.Secret))
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// sign 按钉钉加签规则计算 Base64(HmacSHA256(timestamp + "\n" + secret))。
+func sign(timestamp, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func buildPayload(cfg config.DingTalkConfig, msg notify.Message) payload {
+	title := msg.TaskName
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", msg.TaskName)
+	fmt.Fprintf(&b, "> 时间：%s\n\n", msg.FormattedTime())
+	b.WriteString(msg.Body)
+
+	if cfg.Keyword != "" && !strings.Contains(b.String(), cfg.Keyword) {
+		title = cfg.Keyword + " " + title
+		fmt.Fprintf(&b, "\n\n%s", cfg.Keyword)
+	}
+
+	// 被 @ 的手机号需要同时出现在正文中才会高亮提醒。
+	if len(cfg.AtMobiles) > 0 {
+		b.WriteString("\n\n")
+		for _, mobile := range cfg.AtMobiles {
+			fmt.Fprintf(&b, "@%s ", mobile)
+		}
+	}
+
+	return payload{
+		MsgType: "markdown",
+		Markdown: markdownContent{
+			Title: title,
+			Text:  strings.TrimRight(b.String(), " "),
+		},
+		At: atContent{
+			AtMobiles: cfg.AtMobiles,
+			IsAtAll:   cfg.AtAll,
+		},
+	}
+}
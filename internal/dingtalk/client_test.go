@@ -0,0 +1,74 @@
+package dingtalk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// 期望值由 Base64(HmacSHA256(key=secret, data=timestamp+"\n"+secret)) 独立计算得到。
+	got := sign("1577262236757", "SEC1234567890abcdef")
+	if want := "f7qQ1A8KagEZ45y1gasTtKsEq5ERTeDXP9T+WkIqzDk="; got != want {
+		t.Fatalf("sign() = %q, want %q", got, want)
+	}
+}
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robot/send" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("access_token") != "tok" {
+			_, _ = w.Write([]byte(`{"errcode":300001,"errmsg":"token is not exist"}`))
+			return
+		}
+		timestamp := query.Get("timestamp")
+		if timestamp == "" || query.Get("sign") != sign(timestamp, "SECxyz") {
+			t.Errorf("unexpected signature query: %s", r.URL.RawQuery)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.DingTalkConfig{
+		APIBaseURL:  srv.URL,
+		AccessToken: "tok",
+		Secret:      "SECxyz",
+		Keyword:     "告警",
+		AtMobiles:   []string{"13800138000"},
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "完成", Level: notify.LevelSuccess}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if got.Markdown.Title != "告警 构建" || !strings.Contains(got.Markdown.Text, "\n\n告警") {
+		t.Fatalf("keyword not appended: %+v", got.Markdown)
+	}
+	if !strings.HasSuffix(got.Markdown.Text, "@13800138000") || got.At.AtMobiles[0] != "13800138000" {
+		t.Fatalf("unexpected at mobiles: %+v", got)
+	}
+
+	cfg.AccessToken = "wrong"
+	err := SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "errcode 300001") {
+		t.Fatalf("expected errcode error, got %v", err)
+	}
+}
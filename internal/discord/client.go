@@ -0,0 +1,139 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+const (
+	// maxAttempts 是遇到 429 限流时的最大请求次数（含首次）。
+	maxAttempts = 3
+	// maxRetryAfter 限制单次限流等待时长，避免阻塞工具调用过久。
+	maxRetryAfter = 10 * time.Second
+)
+
+var levelColors = map[notify.Level]int{
+	notify.LevelInfo:     0x3498DB,
+	notify.LevelSuccess:  0x2ECC71,
+	notify.LevelWarning:  0xF1C40F,
+	notify.LevelError:    0xE74C3C,
+	notify.LevelCritical: 0x992D22,
+}
+
+type embedFooter struct {
+	Text string `json:"text"`
+}
+
+type embed struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Color       int         `json:"color"`
+	Footer      embedFooter `json:"footer"`
+	Timestamp   string      `json:"timestamp"`
+}
+
+type payload struct {
+	Username  string  `json:"username,omitempty"`
+	AvatarURL string  `json:"avatar_url,omitempty"`
+	Embeds    []embed `json:"embeds"`
+}
+
+type rateLimitResponse struct {
+	RetryAfter float64 `json:"retry_after"`
+}
+
+// SendMessage posts an embed to the configured Discord webhook, retrying on 429 responses.
+func SendMessage(ctx context.Context, cfg config.DiscordConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode discord payload: %w", err)
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	for attempt := 1; ; attempt++ {
+		wait, err := post(ctx, client, cfg.WebhookURL, body)
+		if err != nil || wait == 0 {
+			return err
+		}
+		if attempt >= maxAttempts {
+			return fmt.Errorf("discord rate limited after %d attempts", attempt)
+		}
+		if wait > maxRetryAfter {
+			return fmt.Errorf("discord rate limited, retry after %s", wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// post 发送一次请求；若被限流则返回需要等待的时长。
+func post(ctx context.Context, client *http.Client, url string, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build discord request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("call discord: %w", err)
+	}
+	defer resp.Body.Close()
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return retryAfter(resp.Header, detail), nil
+	}
+	if resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("discord responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return 0, nil
+}
+
+// retryAfter 优先读取响应体中的 retry_after（秒，可含小数），其次读取 Retry-After 头。
+func retryAfter(header http.Header, body []byte) time.Duration {
+	var rl rateLimitResponse
+	if err := json.Unmarshal(body, &rl); err == nil && rl.RetryAfter > 0 {
+		return time.Duration(rl.RetryAfter * float64(time.Second))
+	}
+	if seconds, err := strconv.ParseFloat(header.Get("Retry-After"), 64); err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	return time.Second
+}
+
+func buildPayload(cfg config.DiscordConfig, msg notify.Message) payload {
+	color, ok := levelColors[msg.Level]
+	if !ok {
+		color = levelColors[notify.LevelInfo]
+	}
+	return payload{
+		Username:  cfg.Username,
+		AvatarURL: cfg.AvatarURL,
+		Embeds: []embed{
+			{
+				Title:       msg.TaskName,
+				Description: msg.Body,
+				Color:       color,
+				Footer:      embedFooter{Text: msg.FormattedTime()},
+				Timestamp:   msg.Time.Format(time.RFC3339),
+			},
+		},
+	}
+}
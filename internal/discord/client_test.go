@@ -0,0 +1,109 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageRetriesOnRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.05,"global":false}`))
+			return
+		}
+
+		var p payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		if len(p.Embeds) != 1 || p.Embeds[0].Title != "构建" || p.Embeds[0].Color != levelColors[notify.LevelError] {
+			t.Errorf("unexpected payload: %+v", p)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	msg := notify.Message{
+		Time:     time.Now(),
+		TaskName: "构建",
+		Body:     "失败",
+		Level:    notify.LevelError,
+	}
+	if err := SendMessage(context.Background(), config.DiscordConfig{WebhookURL: srv.URL}, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestSendMessageErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		calls  int32
+		want   string
+	}{
+		{
+			name:   "unknown webhook",
+			status: http.StatusNotFound,
+			body:   `{"message":"Unknown Webhook","code":10015}`,
+			calls:  1,
+			want:   "404 Not Found: {\"message\":\"Unknown Webhook\"",
+		},
+		{
+			name:   "retry after exceeds cap",
+			status: http.StatusTooManyRequests,
+			body:   `{"message":"You are being rate limited.","retry_after":60,"global":true}`,
+			calls:  1,
+			want:   "retry after 1m0s",
+		},
+		{
+			name:   "attempt limit reached",
+			status: http.StatusTooManyRequests,
+			body:   `{"message":"You are being rate limited.","retry_after":0.01,"global":false}`,
+			calls:  maxAttempts,
+			want:   "rate limited after 3 attempts",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(c.status)
+				_, _ = w.Write([]byte(c.body))
+			}))
+			defer srv.Close()
+
+			msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "失败", Level: notify.LevelError}
+			err := SendMessage(context.Background(), config.DiscordConfig{WebhookURL: srv.URL}, msg)
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Fatalf("expected error containing %q, got %v", c.want, err)
+			}
+			if got := calls.Load(); got != c.calls {
+				t.Fatalf("expected %d calls, got %d", c.calls, got)
+			}
+		})
+	}
+}
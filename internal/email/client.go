@@ -0,0 +1,223 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+const (
+	dialTimeout    = 15 * time.Second
+	sessionTimeout = time.Minute
+)
+
+var htmlTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif;">
+<table cellpadding="6" style="border-collapse: collapse;">
+<tr><th align="left">时间</th><td>{{.FormattedTime}}</td></tr>
+<tr><th align="left">任务</th><td>{{.TaskName}}</td></tr>
+</table>
+<p style="white-space: pre-wrap;">{{.Body}}</p>
+</body>
+</html>
+`))
+
+// SendMessage delivers the notification as a multipart text+HTML email via SMTP.
+func SendMessage(ctx context.Context, cfg config.EmailConfig, msg notify.Message) error {
+	data, err := buildMessage(cfg, msg)
+	if err != nil {
+		return err
+	}
+
+	client, err := dial(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	// 工具调用被取消时立即断开连接，中止阻塞中的 SMTP 命令。
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	if cfg.Username != "" {
+		auth := smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return fmt.Errorf("parse email from address: %w", err)
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	for _, rcpt := range append(append([]string(nil), cfg.To...), cfg.Cc...) {
+		addr, err := mail.ParseAddress(rcpt)
+		if err != nil {
+			return fmt.Errorf("parse email recipient: %w", err)
+		}
+		if err := client.Rcpt(addr.Address); err != nil {
+			return fmt.Errorf("smtp rcpt to %s: %w", addr.Address, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	return client.Quit()
+}
+
+// dial 建立 SMTP 连接并按配置完成 TLS 协商，连接受 ctx 控制。
+func dial(ctx context.Context, cfg config.EmailConfig) (*smtp.Client, error) {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	tlsConfig := &tls.Config{ServerName: cfg.Host}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if cfg.Security == config.EmailSecurityTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect smtp server: %w", err)
+	}
+
+	// net/smtp 不支持 context，这里通过连接截止时间保证整个会话不会无限阻塞。
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(sessionTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("set smtp deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("smtp handshake: %w", err)
+	}
+
+	if cfg.Security == config.EmailSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("smtp server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	return client, nil
+}
+
+func buildMessage(cfg config.EmailConfig, msg notify.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	from, err := formatAddresses([]string{cfg.From})
+	if err != nil {
+		return nil, err
+	}
+	to, err := formatAddresses(cfg.To)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := formatAddresses(cfg.Cc)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := []struct{ key, value string }{
+		{"From", from},
+		{"To", to},
+		{"Cc", cc},
+		{"Subject", mime.QEncoding.Encode("utf-8", "[notify-mcp] "+msg.TaskName)},
+		{"Date", msg.Time.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary())},
+	}
+	for _, h := range headers {
+		if h.value != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", h.key, h.value)
+		}
+	}
+	buf.WriteString("\r\n")
+
+	if err := writePart(mw, "text/plain", []byte(msg.Text())); err != nil {
+		return nil, err
+	}
+
+	var html bytes.Buffer
+	if err := htmlTemplate.Execute(&html, msg); err != nil {
+		return nil, fmt.Errorf("render email html: %w", err)
+	}
+	if err := writePart(mw, "text/html", html.Bytes()); err != nil {
+		return nil, err
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("encode email body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writePart(mw *multipart.Writer, contentType string, content []byte) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType+"; charset=utf-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("encode email part: %w", err)
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write(content); err != nil {
+		return fmt.Errorf("encode email part: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("encode email part: %w", err)
+	}
+	return nil
+}
+
+// formatAddresses 规范化地址列表，非 ASCII 的显示名会按 RFC 2047 编码。
+func formatAddresses(addrs []string) (string, error) {
+	formatted := make([]string, 0, len(addrs))
+	for _, raw := range addrs {
+		addr, err := mail.ParseAddress(raw)
+		if err != nil {
+			return "", fmt.Errorf("parse email address %q: %w", raw, err)
+		}
+		formatted = append(formatted, addr.String())
+	}
+	return strings.Join(formatted, ", "), nil
+}
@@ -0,0 +1,160 @@
+package email
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type receivedMail struct {
+	auth  string
+	from  string
+	rcpts []string
+	data  []byte
+}
+
+// startFakeSMTP 启动一个只处理单个会话的最小 SMTP 服务，用于替代真实邮件服务器。
+func startFakeSMTP(t *testing.T) (int, <-chan receivedMail) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	result := make(chan receivedMail, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		var got receivedMail
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"):
+				_ = tp.PrintfLine("250-localhost")
+				_ = tp.PrintfLine("250 AUTH PLAIN")
+			case strings.HasPrefix(cmd, "AUTH PLAIN"):
+				decoded, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(line[len("AUTH PLAIN"):]))
+				got.auth = string(decoded)
+				_ = tp.PrintfLine("235 2.7.0 Authentication successful")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				got.from = line[len("MAIL FROM:"):]
+				_ = tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				got.rcpts = append(got.rcpts, line[len("RCPT TO:"):])
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				got.data, err = tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				result <- got
+				return
+			default:
+				_ = tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().(*net.TCPAddr).Port, result
+}
+
+func TestSendMessageMultipart(t *testing.T) {
+	t.Parallel()
+
+	port, result := startFakeSMTP(t)
+	cfg := config.EmailConfig{
+		Host:     "127.0.0.1",
+		Port:     port,
+		Security: config.EmailSecurityNone,
+		Username: "bot",
+		Password: "secret",
+		From:     "通知助手 <bot@example.com>",
+		To:       []string{"alice@example.com", "bob@example.com"},
+		Cc:       []string{"carol@example.com"},
+	}
+	msg := notify.Message{
+		Time:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.Local),
+		TaskName: "代码评审",
+		Body:     "<等待确认>",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := SendMessage(ctx, cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	got := <-result
+	if got.auth != "\x00bot\x00secret" {
+		t.Fatalf("unexpected auth %q", got.auth)
+	}
+	if got.from != "<bot@example.com>" {
+		t.Fatalf("unexpected sender %q", got.from)
+	}
+	if strings.Join(got.rcpts, ",") != "<alice@example.com>,<bob@example.com>,<carol@example.com>" {
+		t.Fatalf("unexpected recipients %v", got.rcpts)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(got.data)))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "[notify-mcp] 代码评审" {
+		t.Fatalf("unexpected subject %q (%v)", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type %q (%v)", mediaType, err)
+	}
+
+	parts := map[string]string{}
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[partType] = string(body)
+	}
+
+	if text := parts["text/plain"]; !strings.Contains(text, "任务：代码评审") || !strings.Contains(text, "<等待确认>") {
+		t.Fatalf("unexpected text part %q", text)
+	}
+	if html := parts["text/html"]; !strings.Contains(html, "&lt;等待确认&gt;") || !strings.Contains(html, "2026-01-02 03:04:05") {
+		t.Fatalf("unexpected html part %q", html)
+	}
+}
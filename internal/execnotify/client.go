@@ -0,0 +1,99 @@
+package execnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+// maxOutput 限制读取子进程输出的字节数，避免异常脚本占满内存。
+const maxOutput = 4096
+
+type payload struct {
+	TaskName  string `json:"taskName"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+	Host      string `json:"host"`
+	Level     string `json:"level"`
+}
+
+// SendMessage runs the configured command, passing the notification through
+// NOTIFY_* environment variables and a JSON payload on stdin.
+func SendMessage(ctx context.Context, cfg config.ExecConfig, msg notify.Message) error {
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	if timeout == 0 {
+		timeout = config.DefaultExecTimeoutSeconds * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	host, _ := os.Hostname()
+	timestamp := msg.Time.Format(time.RFC3339)
+	input, err := json.Marshal(payload{
+		TaskName:  msg.TaskName,
+		Message:   msg.Body,
+		Timestamp: timestamp,
+		Host:      host,
+		Level:     string(msg.Level),
+	})
+	if err != nil {
+		return fmt.Errorf("encode exec payload: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, cfg.Command, cfg.Args...)
+	cmd.Env = append(os.Environ(),
+		"NOTIFY_TASK="+msg.TaskName,
+		"NOTIFY_MESSAGE="+msg.Body,
+		"NOTIFY_LEVEL="+string(msg.Level),
+		"NOTIFY_TIMESTAMP="+timestamp,
+	)
+	cmd.Stdin = bytes.NewReader(input)
+	// stdout 承载 MCP stdio 传输，子进程输出必须被截获，不能继承父进程的标准输出。
+	cmd.Stdout = io.Discard
+	var stderr limitedBuffer
+	cmd.Stderr = &stderr
+	// 子进程派生的后台进程可能继续占用管道，超时后不再等待其退出。
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("command %s timed out after %s", cfg.Command, timeout)
+	}
+	detail := strings.TrimSpace(stderr.String())
+	if err != nil {
+		if detail != "" {
+			return fmt.Errorf("run command %s: %w: %s", cfg.Command, err, detail)
+		}
+		return fmt.Errorf("run command %s: %w", cfg.Command, err)
+	}
+	if detail != "" {
+		return fmt.Errorf("command %s wrote to stderr: %s", cfg.Command, detail)
+	}
+	return nil
+}
+
+// limitedBuffer 只保留前 maxOutput 字节，多余内容直接丢弃但不报错，保证子进程不会因管道阻塞。
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remain := maxOutput - b.Len(); remain > 0 {
+		if len(p) > remain {
+			b.Buffer.Write(p[:remain])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
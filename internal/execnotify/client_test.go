@@ -0,0 +1,59 @@
+//go:build !windows
+
+package execnotify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out")
+	msg := notify.Message{
+		Time:     time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC),
+		TaskName: "部署",
+		Body:     "完成",
+		Level:    notify.LevelSuccess,
+	}
+	cfg := config.ExecConfig{
+		Command: "/bin/sh",
+		Args:    []string{"-c", `printf '%s|%s|%s|%s|' "$NOTIFY_TASK" "$NOTIFY_MESSAGE" "$NOTIFY_LEVEL" "$NOTIFY_TIMESTAMP" > "$0"; cat >> "$0"`, out},
+	}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(got), "部署|完成|success|2024-05-01T08:30:00Z|{") ||
+		!strings.Contains(string(got), `"taskName":"部署"`) {
+		t.Fatalf("unexpected command input: %s", got)
+	}
+}
+
+func TestSendMessageFailures(t *testing.T) {
+	t.Parallel()
+
+	msg := notify.Message{Time: time.Now(), TaskName: "部署", Level: notify.LevelInfo}
+	cases := map[string]config.ExecConfig{
+		"exit":    {Command: "/bin/sh", Args: []string{"-c", "exit 3"}},
+		"stderr":  {Command: "/bin/sh", Args: []string{"-c", "echo oops >&2"}},
+		"timeout": {Command: "/bin/sh", Args: []string{"-c", "sleep 5"}, TimeoutSeconds: 1},
+	}
+	for name, cfg := range cases {
+		if err := SendMessage(context.Background(), cfg, msg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
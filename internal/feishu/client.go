@@ -0,0 +1,173 @@
+package feishu
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+var levelTemplates = map[notify.Level]string{
+	notify.LevelInfo:     "blue",
+	notify.LevelSuccess:  "green",
+	notify.LevelWarning:  "orange",
+	notify.LevelError:    "red",
+	notify.LevelCritical: "carmine",
+}
+
+type text struct {
+	Tag     string `json:"tag"`
+	Content string `json:"content"`
+}
+
+type field struct {
+	IsShort bool `json:"is_short"`
+	Text    text `json:"text"`
+}
+
+type action struct {
+	Tag  string `json:"tag"`
+	Text text   `json:"text"`
+	URL  string `json:"url"`
+	Type string `json:"type"`
+}
+
+type element struct {
+	Tag     string   `json:"tag"`
+	Text    *text    `json:"text,omitempty"`
+	Fields  []field  `json:"fields,omitempty"`
+	Actions []action `json:"actions,omitempty"`
+}
+
+type header struct {
+	Title    text   `json:"title"`
+	Template string `json:"template"`
+}
+
+type card struct {
+	Config   map[string]bool `json:"config"`
+	Header   header          `json:"header"`
+	Elements []element       `json:"elements"`
+}
+
+type payload struct {
+	Timestamp string `json:"timestamp,omitempty"`
+	Sign      string `json:"sign,omitempty"`
+	MsgType   string `json:"msg_type"`
+	Card      card   `json:"card"`
+}
+
+type response struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// SendMessage posts an interactive card to the configured Feishu/Lark bot.
+func SendMessage(ctx context.Context, cfg config.FeishuConfig, msg notify.Message) error {
+	p := buildPayload(cfg, msg)
+	if cfg.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		sig, err := sign(timestamp, cfg.Secret)
+		if err != nil {
+			return err
+		}
+		p.Timestamp = timestamp
+		p.Sign = sig
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encode feishu payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build feishu request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call feishu: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("feishu responded with %s", resp.Status)
+	}
+
+	// 签名校验失败、关键词不匹配等错误均以 HTTP 200 + 非 0 code 返回。
+	var result response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode feishu response: %w", err)
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("feishu responded with code %d: %s", result.Code, result.Msg)
+	}
+	return nil
+}
+
+// sign 按飞书签名规则，以 timestamp + "\n" + secret 作为 HmacSHA256 的密钥对空串签名。
+func sign(timestamp, secret string) (string, error) {
+	mac := hmac.New(sha256.New, []byte(timestamp+"\n"+secret))
+	if _, err := mac.Write(nil); err != nil {
+		return "", fmt.Errorf("sign feishu request: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func buildPayload(cfg config.FeishuConfig, msg notify.Message) payload {
+	template, ok := levelTemplates[msg.Level]
+	if !ok {
+		template = levelTemplates[notify.LevelInfo]
+	}
+
+	elements := []element{
+		{
+			Tag: "div",
+			Fields: []field{
+				{IsShort: true, Text: text{Tag: "lark_md", Content: "**时间**\n" + msg.FormattedTime()}},
+				{IsShort: true, Text: text{Tag: "lark_md", Content: "**任务**\n" + msg.TaskName}},
+			},
+		},
+		{
+			Tag:  "div",
+			Text: &text{Tag: "lark_md", Content: msg.Body},
+		},
+	}
+	if cfg.LinkURL != "" {
+		elements = append(elements, element{
+			Tag: "action",
+			Actions: []action{
+				{
+					Tag:  "button",
+					Text: text{Tag: "plain_text", Content: "查看详情"},
+					URL:  cfg.LinkURL,
+					Type: "primary",
+				},
+			},
+		})
+	}
+
+	return payload{
+		MsgType: "interactive",
+		Card: card{
+			Config: map[string]bool{"wide_screen_mode": true},
+			Header: header{
+				Title:    text{Tag: "plain_text", Content: msg.TaskName},
+				Template: template,
+			},
+			Elements: elements,
+		},
+	}
+}
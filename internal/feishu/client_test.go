@@ -0,0 +1,61 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// 与钉钉不同，飞书以 timestamp+"\n"+secret 作为密钥对空串签名；
+	// 期望值由 Base64(HmacSHA256(key=timestamp+"\n"+secret, data="")) 独立计算得到。
+	got, err := sign("1577262236757", "SEC1234567890abcdef")
+	if err != nil {
+		t.Fatalf("sign returned error: %v", err)
+	}
+	if want := "cdnQKdsdyB3PZ/gFwusJvlGnn6dIdccHf+2qLf1VStE="; got != want {
+		t.Fatalf("sign() = %q, want %q", got, want)
+	}
+}
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		want, _ := sign(got.Timestamp, "secret")
+		if got.Sign != want {
+			_, _ = w.Write([]byte(`{"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.FeishuConfig{WebhookURL: srv.URL, Secret: "secret", LinkURL: "https://ci.example.com"}
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "失败", Level: notify.LevelError}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if got.MsgType != "interactive" || got.Card.Header.Template != "red" || got.Card.Header.Title.Content != "构建" {
+		t.Fatalf("unexpected card: %+v", got)
+	}
+
+	cfg.Secret = "other"
+	err := SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "code 19021") {
+		t.Fatalf("expected code error, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package googlechat
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type decoratedText struct {
+	TopLabel string `json:"topLabel"`
+	Text     string `json:"text"`
+}
+
+type textParagraph struct {
+	Text string `json:"text"`
+}
+
+type widget struct {
+	DecoratedText *decoratedText `json:"decoratedText,omitempty"`
+	TextParagraph *textParagraph `json:"textParagraph,omitempty"`
+}
+
+type section struct {
+	Widgets []widget `json:"widgets"`
+}
+
+type cardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+}
+
+type card struct {
+	Header   cardHeader `json:"header"`
+	Sections []section  `json:"sections"`
+}
+
+type cardV2 struct {
+	CardID string `json:"cardId"`
+	Card   card   `json:"card"`
+}
+
+type thread struct {
+	ThreadKey string `json:"threadKey"`
+}
+
+type payload struct {
+	Text    string   `json:"text"`
+	CardsV2 []cardV2 `json:"cardsV2"`
+	Thread  thread   `json:"thread"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// SendMessage posts a cardsV2 message to the configured Google Chat space.
+// Notifications for the same task share a thread key and land in one thread.
+func SendMessage(ctx context.Context, cfg config.GoogleChatConfig, msg notify.Message) error {
+	endpoint, err := url.Parse(cfg.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("parse googlechat webhook url: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("messageReplyOption", "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+	endpoint.RawQuery = query.Encode()
+
+	body, err := json.Marshal(buildPayload(msg))
+	if err != nil {
+		return fmt.Errorf("encode googlechat payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build googlechat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call googlechat: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var result errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && result.Error.Message != "" {
+			return fmt.Errorf("googlechat responded with %s: %s", resp.Status, result.Error.Message)
+		}
+		return fmt.Errorf("googlechat responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// threadKey 根据任务名称生成稳定的线程 key，避免特殊字符与长度限制带来的问题。
+func threadKey(taskName string) string {
+	sum := sha256.Sum256([]byte(taskName))
+	return "notify-mcp-" + hex.EncodeToString(sum[:8])
+}
+
+func buildPayload(msg notify.Message) payload {
+	return payload{
+		Text: msg.TaskName,
+		CardsV2: []cardV2{
+			{
+				CardID: "notify-mcp",
+				Card: card{
+					Header: cardHeader{
+						Title:    msg.TaskName,
+						Subtitle: string(msg.Level),
+					},
+					Sections: []section{
+						{
+							Widgets: []widget{
+								{DecoratedText: &decoratedText{TopLabel: "时间", Text: msg.FormattedTime()}},
+								{DecoratedText: &decoratedText{TopLabel: "任务", Text: msg.TaskName}},
+								{TextParagraph: &textParagraph{Text: msg.Body}},
+							},
+						},
+					},
+				},
+			},
+		},
+		Thread: thread{ThreadKey: threadKey(msg.TaskName)},
+	}
+}
@@ -0,0 +1,95 @@
+package googlechat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		payloads []payload
+		queries  []url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/spaces/AAAA/messages" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var p payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		payloads = append(payloads, p)
+		queries = append(queries, r.URL.Query())
+		_, _ = w.Write([]byte(`{"name":"spaces/AAAA/messages/1"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.GoogleChatConfig{WebhookURL: srv.URL + "/v1/spaces/AAAA/messages?key=K3Y&token=T0K%3D"}
+	first := notify.Message{Time: time.Now(), TaskName: "夜间构建", Body: "开始", Level: notify.LevelInfo}
+	second := notify.Message{Time: time.Now(), TaskName: "夜间构建", Body: "失败", Level: notify.LevelError}
+	other := notify.Message{Time: time.Now(), TaskName: "数据同步", Body: "完成", Level: notify.LevelSuccess}
+	for _, msg := range []notify.Message{first, second, other} {
+		if err := SendMessage(context.Background(), cfg, msg); err != nil {
+			t.Fatalf("SendMessage returned error: %v", err)
+		}
+	}
+
+	for i, q := range queries {
+		if q.Get("key") != "K3Y" || q.Get("token") != "T0K=" {
+			t.Fatalf("request %d dropped webhook credentials: %v", i, q)
+		}
+		if q.Get("messageReplyOption") != "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD" {
+			t.Fatalf("request %d missing reply option: %v", i, q)
+		}
+	}
+
+	p := payloads[1]
+	if p.Text != "夜间构建" || len(p.CardsV2) != 1 {
+		t.Fatalf("unexpected payload: %+v", p)
+	}
+	c := p.CardsV2[0].Card
+	if c.Header.Title != "夜间构建" || c.Header.Subtitle != "error" {
+		t.Fatalf("unexpected card header: %+v", c.Header)
+	}
+	widgets := c.Sections[0].Widgets
+	if len(widgets) != 3 || widgets[0].DecoratedText.Text != second.FormattedTime() ||
+		widgets[1].DecoratedText.Text != "夜间构建" || widgets[2].TextParagraph.Text != "失败" {
+		t.Fatalf("unexpected widgets: %+v", widgets)
+	}
+
+	// 同一任务的通知落在同一线程，不同任务使用不同线程。
+	if payloads[0].Thread.ThreadKey == "" || payloads[0].Thread.ThreadKey != payloads[1].Thread.ThreadKey {
+		t.Fatalf("thread keys differ for the same task: %q vs %q", payloads[0].Thread.ThreadKey, payloads[1].Thread.ThreadKey)
+	}
+	if payloads[2].Thread.ThreadKey == payloads[0].Thread.ThreadKey {
+		t.Fatalf("different tasks share thread key %q", payloads[2].Thread.ThreadKey)
+	}
+}
+
+func TestSendMessageError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":400,"message":"Invalid JSON payload received.","status":"INVALID_ARGUMENT"}}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.GoogleChatConfig{WebhookURL: srv.URL + "/v1/spaces/AAAA/messages?key=K3Y&token=T0K"}
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "失败", Level: notify.LevelError}
+	err := SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "Invalid JSON payload received.") {
+		t.Fatalf("expected api error message, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package gotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type payload struct {
+	Title    string         `json:"title"`
+	Message  string         `json:"message"`
+	Priority *int           `json:"priority,omitempty"`
+	Extras   map[string]any `json:"extras"`
+}
+
+// SendMessage posts a markdown message to the configured Gotify server.
+func SendMessage(ctx context.Context, cfg config.GotifyConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode gotify payload: %w", err)
+	}
+
+	endpoint := strings.TrimRight(cfg.ServerURL, "/") + "/message"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build gotify request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", cfg.Token)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call gotify: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("gotify responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func buildPayload(cfg config.GotifyConfig, msg notify.Message) payload {
+	return payload{
+		Title:    msg.TaskName,
+		Message:  fmt.Sprintf("%s\n\n**时间**：%s", msg.Body, msg.FormattedTime()),
+		Priority: cfg.Priority,
+		Extras: map[string]any{
+			"client::display": map[string]string{"contentType": "text/markdown"},
+		},
+	}
+}
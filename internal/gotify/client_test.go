@@ -0,0 +1,63 @@
+package gotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/message" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if key := r.Header.Get("X-Gotify-Key"); key != "app-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer srv.Close()
+
+	priority := 8
+	cfg := config.GotifyConfig{ServerURL: srv.URL + "/", Token: "app-token", Priority: &priority}
+	msg := notify.Message{Time: time.Now(), TaskName: "迁移", Body: "等待确认"}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if got["title"] != "迁移" || got["priority"] != float64(8) {
+		t.Fatalf("unexpected payload: %v", got)
+	}
+	extras, _ := got["extras"].(map[string]any)
+	display, _ := extras["client::display"].(map[string]any)
+	if display["contentType"] != "text/markdown" {
+		t.Fatalf("unexpected extras: %v", got["extras"])
+	}
+
+	// 显式配置的 0（静默）也必须发送，不能被省略。
+	priority = 0
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if value, ok := got["priority"]; !ok || value != float64(0) {
+		t.Fatalf("expected explicit priority 0, got %v", got)
+	}
+
+	cfg.Token = "wrong"
+	if err := SendMessage(context.Background(), cfg, msg); err == nil {
+		t.Fatal("expected error for rejected token")
+	}
+}
@@ -0,0 +1,94 @@
+package homeassistant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type action struct {
+	Action string `json:"action"`
+	Title  string `json:"title"`
+	URI    string `json:"uri"`
+}
+
+type pushData struct {
+	InterruptionLevel string `json:"interruption-level"`
+}
+
+// data 对应伴侣应用的通知参数，priority/ttl 作用于 Android，push 作用于 iOS。
+type data struct {
+	Tag      string    `json:"tag,omitempty"`
+	Priority string    `json:"priority,omitempty"`
+	TTL      *int      `json:"ttl,omitempty"`
+	Push     *pushData `json:"push,omitempty"`
+	Actions  []action  `json:"actions,omitempty"`
+}
+
+type payload struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	Data    *data  `json:"data,omitempty"`
+}
+
+// SendMessage calls the configured notify service through the Home Assistant REST API.
+func SendMessage(ctx context.Context, cfg config.HomeAssistantConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode homeassistant payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/api/services/notify/%s", strings.TrimRight(cfg.ServerURL, "/"), url.PathEscape(cfg.Service))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build homeassistant request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cfg.Token)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call homeassistant: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		// 服务名错误时返回 400 "Service not found"，令牌无效时返回 401。
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("homeassistant responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func buildPayload(cfg config.HomeAssistantConfig, msg notify.Message) payload {
+	p := payload{
+		Title:   msg.TaskName,
+		Message: fmt.Sprintf("%s\n时间：%s", msg.Body, msg.FormattedTime()),
+	}
+
+	d := data{Tag: cfg.Tag}
+	if cfg.Priority == config.HomeAssistantPriorityHigh {
+		// ttl 为 0 时 Android 会立即投递，time-sensitive 可突破 iOS 的专注模式。
+		ttl := 0
+		d.Priority = config.HomeAssistantPriorityHigh
+		d.TTL = &ttl
+		d.Push = &pushData{InterruptionLevel: "time-sensitive"}
+	}
+	for _, a := range cfg.Actions {
+		d.Actions = append(d.Actions, action{Action: "URI", Title: a.Title, URI: a.URI})
+	}
+	if d.Tag != "" || d.Priority != "" || len(d.Actions) > 0 {
+		p.Data = &d
+	}
+	return p
+}
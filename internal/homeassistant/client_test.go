@@ -0,0 +1,62 @@
+package homeassistant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/services/notify/mobile_app_pixel" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Service not found."))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer llat" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	cfg := config.HomeAssistantConfig{
+		ServerURL: srv.URL,
+		Token:     "llat",
+		Service:   "mobile_app_pixel",
+		Priority:  config.HomeAssistantPriorityHigh,
+		Tag:       "agent",
+		Actions:   []config.HomeAssistantAction{{Title: "打开", URI: "https://example.com"}},
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "迁移", Body: "等待确认"}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	data, _ := got["data"].(map[string]any)
+	if got["title"] != "迁移" || data["tag"] != "agent" || data["priority"] != "high" || data["ttl"] != float64(0) {
+		t.Fatalf("unexpected payload: %v", got)
+	}
+	actions, _ := data["actions"].([]any)
+	if len(actions) != 1 || actions[0].(map[string]any)["action"] != "URI" {
+		t.Fatalf("unexpected actions: %v", data["actions"])
+	}
+
+	cfg.Service = "missing"
+	if err := SendMessage(context.Background(), cfg, msg); err == nil {
+		t.Fatal("expected error for unknown service")
+	}
+}
@@ -0,0 +1,102 @@
+package lognotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+// mu 串行化同一进程内的写入与轮转，避免并发的工具调用交错写入同一行。
+var mu sync.Mutex
+
+type entry struct {
+	Timestamp string `json:"timestamp"`
+	TaskName  string `json:"taskName"`
+	Message   string `json:"message"`
+	Level     string `json:"level"`
+	Host      string `json:"host"`
+}
+
+// SendMessage appends the notification as one JSON line to the configured file,
+// rotating the file first when it would exceed the size limit.
+func SendMessage(ctx context.Context, cfg config.LogConfig, msg notify.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	host, _ := os.Hostname()
+	line, err := json.Marshal(entry{
+		Timestamp: msg.Time.Format(time.RFC3339),
+		TaskName:  msg.TaskName,
+		Message:   msg.Body,
+		Level:     string(msg.Level),
+		Host:      host,
+	})
+	if err != nil {
+		return fmt.Errorf("encode log entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return fmt.Errorf("create log dir: %w", err)
+	}
+	if cfg.MaxSizeMB > 0 {
+		info, err := os.Stat(cfg.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("stat log file: %w", err)
+		}
+		if err == nil && info.Size()+int64(len(line)) > int64(cfg.MaxSizeMB)<<20 {
+			if err := rotate(cfg.Path, cfg.MaxBackups); err != nil {
+				return err
+			}
+		}
+	}
+
+	f, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("write log file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+	return nil
+}
+
+// rotate 将 path 依次后移为 path.1 ... path.N，超出 backups 的最旧文件被删除。
+// 当前日志总会被保留为 path.1，backups 小于 1 时拒绝轮转。
+func rotate(path string, backups int) error {
+	if backups < 1 {
+		return errors.New("log rotation requires at least one backup")
+	}
+
+	oldest := fmt.Sprintf("%s.%d", path, backups)
+	if err := os.Remove(oldest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove rotated log file: %w", err)
+	}
+	for i := backups - 1; i >= 1; i-- {
+		src := fmt.Sprintf("%s.%d", path, i)
+		dst := fmt.Sprintf("%s.%d", path, i+1)
+		if err := os.Rename(src, dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotate log file: %w", err)
+		}
+	}
+	if err := os.Rename(path, path+".1"); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	return nil
+}
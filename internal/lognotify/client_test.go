@@ -0,0 +1,90 @@
+package lognotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageRotates(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "logs", "notify.jsonl")
+	cfg := config.LogConfig{Path: path, MaxSizeMB: 1, MaxBackups: 1}
+	msg := notify.Message{Time: time.Now(), TaskName: "迁移", Body: "完成", Level: notify.LevelSuccess}
+
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	var got map[string]string
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(data), &got); err != nil {
+		t.Fatalf("decode log line %q: %v", data, err)
+	}
+	if got["taskName"] != "迁移" || got["level"] != "success" {
+		t.Fatalf("unexpected log entry: %v", got)
+	}
+
+	// 填满当前文件后再次写入，应轮转为 .1，且旧的 .1 被覆盖。
+	if err := os.WriteFile(path, bytes.Repeat([]byte("x"), 1<<20), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path+".1", []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if info, err := os.Stat(path + ".1"); err != nil || info.Size() != 1<<20 {
+		t.Fatalf("expected full file rotated to .1: %v %v", info, err)
+	}
+	if _, err := os.Stat(path + ".2"); !os.IsNotExist(err) {
+		t.Fatalf("expected no second backup, got %v", err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Count(data, []byte("\n")) != 1 {
+		t.Fatalf("expected fresh log file with one line, got %q", data)
+	}
+}
+
+func TestRotationWithoutBackupsKeepsLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "notify.jsonl")
+	cfg := config.LogConfig{Path: path, MaxSizeMB: 1, MaxBackups: 0}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error for rotation without backups")
+	}
+
+	// 即使绕过配置校验，轮转也不能删除当前日志。
+	full := bytes.Repeat([]byte("x"), 1<<20)
+	if err := os.WriteFile(path, full, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "迁移", Body: "完成", Level: notify.LevelSuccess}
+	if err := SendMessage(context.Background(), cfg, msg); err == nil {
+		t.Fatal("expected rotation error")
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, full) {
+		t.Fatalf("live log file was modified: %v", err)
+	}
+
+	cfg.MaxSizeMB = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("backups are not required without rotation: %v", err)
+	}
+}
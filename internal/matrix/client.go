@@ -0,0 +1,128 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+const (
+	// maxAttempts 是网络错误或 5xx 时的最大请求次数（含首次）。
+	maxAttempts  = 3
+	retryBackoff = time.Second
+)
+
+type payload struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+// retryableError 标记可以使用同一事务 ID 重试的错误。
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string { return e.err.Error() }
+
+func (e retryableError) Unwrap() error { return e.err }
+
+// SendMessage sends an m.room.message event to the configured Matrix room.
+// All attempts share one transaction ID, so the homeserver deduplicates retries.
+func SendMessage(ctx context.Context, cfg config.MatrixConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(msg))
+	if err != nil {
+		return fmt.Errorf("encode matrix payload: %w", err)
+	}
+
+	txnID, err := newTxnID()
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		strings.TrimRight(cfg.HomeserverURL, "/"),
+		url.PathEscape(cfg.RoomID),
+		url.PathEscape(txnID),
+	)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	for attempt := 1; ; attempt++ {
+		err = put(ctx, client, endpoint, cfg.AccessToken, body)
+		var retryable retryableError
+		if err == nil || !errors.As(err, &retryable) || attempt >= maxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(retryBackoff * time.Duration(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func put(ctx context.Context, client *http.Client, endpoint, token string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build matrix request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("call matrix: %w", err)
+		}
+		return retryableError{fmt.Errorf("call matrix: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		err := fmt.Errorf("matrix responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			return retryableError{err}
+		}
+		return err
+	}
+	return nil
+}
+
+func newTxnID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate matrix transaction id: %w", err)
+	}
+	return fmt.Sprintf("notify-mcp-%d-%s", time.Now().UnixNano(), hex.EncodeToString(buf)), nil
+}
+
+func buildPayload(msg notify.Message) payload {
+	formatted := fmt.Sprintf("<strong>%s</strong><br>时间：%s<br>%s",
+		html.EscapeString(msg.TaskName),
+		html.EscapeString(msg.FormattedTime()),
+		strings.ReplaceAll(html.EscapeString(msg.Body), "\n", "<br>"),
+	)
+	return payload{
+		MsgType:       "m.text",
+		Body:          msg.Text(),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formatted,
+	}
+}
@@ -0,0 +1,62 @@
+package matrix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageRetriesWithSameTxnID(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer syt_token" {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+
+		mu.Lock()
+		paths = append(paths, r.URL.EscapedPath())
+		first := len(paths) == 1
+		mu.Unlock()
+
+		if first {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"event_id":"$abc"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.MatrixConfig{
+		HomeserverURL: srv.URL,
+		AccessToken:   "syt_token",
+		RoomID:        "!room:example.org",
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "同步", Body: "完成"}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(paths))
+	}
+	if paths[0] != paths[1] {
+		t.Fatalf("retry used a different transaction: %q vs %q", paths[0], paths[1])
+	}
+	if !strings.HasPrefix(paths[0], "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/") {
+		t.Fatalf("unexpected path %q", paths[0])
+	}
+}
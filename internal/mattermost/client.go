@@ -0,0 +1,97 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+var levelColors = map[notify.Level]string{
+	notify.LevelInfo:     "#3498DB",
+	notify.LevelSuccess:  "#2ECC71",
+	notify.LevelWarning:  "#F1C40F",
+	notify.LevelError:    "#E74C3C",
+	notify.LevelCritical: "#992D22",
+}
+
+type field struct {
+	Short bool   `json:"short"`
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type attachment struct {
+	Fallback string  `json:"fallback"`
+	Color    string  `json:"color"`
+	Title    string  `json:"title"`
+	Text     string  `json:"text"`
+	Fields   []field `json:"fields"`
+}
+
+type payload struct {
+	Channel     string       `json:"channel,omitempty"`
+	Username    string       `json:"username,omitempty"`
+	IconURL     string       `json:"icon_url,omitempty"`
+	IconEmoji   string       `json:"icon_emoji,omitempty"`
+	Attachments []attachment `json:"attachments"`
+}
+
+// SendMessage posts a markdown attachment to the configured Mattermost incoming webhook.
+func SendMessage(ctx context.Context, cfg config.MattermostConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode mattermost payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build mattermost request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call mattermost: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("mattermost responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func buildPayload(cfg config.MattermostConfig, msg notify.Message) payload {
+	color, ok := levelColors[msg.Level]
+	if !ok {
+		color = levelColors[notify.LevelInfo]
+	}
+	return payload{
+		Channel:   cfg.Channel,
+		Username:  cfg.Username,
+		IconURL:   cfg.IconURL,
+		IconEmoji: cfg.IconEmoji,
+		Attachments: []attachment{
+			{
+				Fallback: msg.Text(),
+				Color:    color,
+				Title:    msg.TaskName,
+				Text:     msg.Body,
+				Fields: []field{
+					{Short: true, Title: "时间", Value: msg.FormattedTime()},
+					{Short: true, Title: "任务", Value: msg.TaskName},
+				},
+			},
+		},
+	}
+}
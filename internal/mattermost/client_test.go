@@ -0,0 +1,91 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	cfg := config.MattermostConfig{
+		WebhookURL: srv.URL,
+		Channel:    "town-square",
+		Username:   "notify-bot",
+		IconURL:    "https://example.com/icon.png",
+		IconEmoji:  ":robot:",
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "**失败**", Level: notify.LevelError}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if got.Channel != cfg.Channel || got.Username != cfg.Username || got.IconURL != cfg.IconURL || got.IconEmoji != cfg.IconEmoji {
+		t.Fatalf("overrides not sent: %+v", got)
+	}
+	if len(got.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %d", len(got.Attachments))
+	}
+	a := got.Attachments[0]
+	if a.Title != "构建" || a.Text != "**失败**" || a.Fallback != msg.Text() || a.Color != "#E74C3C" {
+		t.Fatalf("unexpected attachment: %+v", a)
+	}
+	if len(a.Fields) != 2 || a.Fields[0].Value != msg.FormattedTime() || a.Fields[1].Value != "构建" {
+		t.Fatalf("unexpected fields: %+v", a.Fields)
+	}
+}
+
+func TestBuildPayloadLevelColors(t *testing.T) {
+	t.Parallel()
+
+	for level, color := range levelColors {
+		p := buildPayload(config.MattermostConfig{}, notify.Message{TaskName: "任务", Level: level})
+		if p.Attachments[0].Color != color {
+			t.Errorf("level %s: color %s, want %s", level, p.Attachments[0].Color, color)
+		}
+	}
+	// 未设置的覆盖项不应出现在请求中，以便使用 Webhook 自身的默认值。
+	data, _ := json.Marshal(buildPayload(config.MattermostConfig{}, notify.Message{Level: notify.LevelInfo}))
+	for _, key := range []string{"channel", "username", "icon_url", "icon_emoji"} {
+		if strings.Contains(string(data), `"`+key+`"`) {
+			t.Errorf("empty %s should be omitted: %s", key, data)
+		}
+	}
+}
+
+func TestSendMessageError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"id":"web.incoming_webhook.invalid.app_error","message":"Invalid webhook."}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.MattermostConfig{WebhookURL: srv.URL}
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "失败", Level: notify.LevelError}
+	err := SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "Invalid webhook.") {
+		t.Fatalf("expected webhook error, got %v", err)
+	}
+}
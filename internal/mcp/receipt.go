@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+	"github.com/zboyco/notify-mcp/internal/pushover"
+)
+
+func (s *Server) recordReceipt(taskName, receipt string) {
+	s.receiptsMu.Lock()
+	defer s.receiptsMu.Unlock()
+	s.receipts[taskName] = receipt
+}
+
+func (s *Server) lookupReceipt(taskName string) (string, bool) {
+	s.receiptsMu.Lock()
+	defer s.receiptsMu.Unlock()
+	receipt, ok := s.receipts[taskName]
+	return receipt, ok
+}
+
+func (s *Server) handleAckStatusTool(
+	ctx context.Context,
+	req mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	taskName := strings.TrimSpace(req.GetString(taskNameParam, defaultTaskName))
+	receipt := strings.TrimSpace(req.GetString(receiptParam, ""))
+	subject := fmt.Sprintf("回执 %s 对应", receipt)
+	if receipt == "" {
+		var ok bool
+		if receipt, ok = s.lookupReceipt(taskName); !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("任务 %s 没有待确认的 Pushover 紧急通知，请传入 %s 参数", taskName, receiptParam)), nil
+		}
+		subject = fmt.Sprintf("任务 %s ", taskName)
+	}
+
+	settings, err := config.Load()
+	if err != nil {
+		s.logger.Printf("重新加载配置失败: %v", err)
+		return mcp.NewToolResultError("读取通知配置失败"), nil
+	}
+
+	var pushoverCfg config.PushoverConfig
+	found := false
+	for _, method := range settings.Methods {
+		if method.Type == config.MethodPushover {
+			if pushoverCfg, err = method.PushoverConfig(); err != nil {
+				s.logger.Printf("读取 Pushover 配置失败: %v", err)
+				return mcp.NewToolResultError("读取 Pushover 配置失败"), nil
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		return mcp.NewToolResultError("未配置 Pushover 通知方式"), nil
+	}
+
+	status, err := pushover.GetReceipt(ctx, pushoverCfg, receipt)
+	if err != nil {
+		s.logger.Printf("查询 Pushover 回执 %s 失败: %v", receipt, err)
+		return mcp.NewToolResultError("查询 Pushover 回执失败"), nil
+	}
+
+	switch {
+	case status.Acknowledged:
+		who := status.AcknowledgedBy
+		if who == "" {
+			who = "用户"
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("%s的紧急通知已于 %s 被 %s 确认",
+			subject, status.AcknowledgedAt.Format(notify.TimeLayout), who)), nil
+	case status.Expired:
+		return mcp.NewToolResultText(fmt.Sprintf("%s的紧急通知已过期，用户未确认", subject)), nil
+	default:
+		return mcp.NewToolResultText(fmt.Sprintf("%s的紧急通知尚未被确认，将持续提醒至 %s",
+			subject, status.ExpiresAt.Format(notify.TimeLayout))), nil
+	}
+}
@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+)
+
+// useSettings 将配置写入临时的用户配置目录，供重新加载配置的工具处理函数读取。
+func useSettings(t *testing.T, methods ...config.Method) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("AppData", dir)
+
+	cfgPath, err := config.Path()
+	if err != nil {
+		t.Fatalf("resolve config path: %v", err)
+	}
+	data, err := json.Marshal(config.Settings{Methods: methods})
+	if err != nil {
+		t.Fatalf("encode settings: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfgPath), 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+	if err := os.WriteFile(cfgPath, data, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+func callTool(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) (string, bool) {
+	t.Helper()
+
+	var req mcp.CallToolRequest
+	req.Params.Arguments = args
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("tool handler returned error: %v", err)
+	}
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n"), result.IsError
+}
+
+func TestAckStatusTool(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/1/messages.json":
+			// 以任务标题作为回执 ID，便于按任务区分确认状态。
+			_ = r.ParseForm()
+			_, _ = w.Write([]byte(`{"status":1,"request":"req","receipt":"` + r.PostForm.Get("title") + `"}`))
+		case "/1/receipts/acked.json":
+			_, _ = w.Write([]byte(`{"status":1,"acknowledged":1,"acknowledged_at":1700000100,"acknowledged_by":"alice","expires_at":1700003600}`))
+		case "/1/receipts/expired.json":
+			_, _ = w.Write([]byte(`{"status":1,"expired":1,"expires_at":1700003600}`))
+		case "/1/receipts/pending.json":
+			_, _ = w.Write([]byte(`{"status":1,"expires_at":1700003600}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":0,"errors":["receipt not found"]}`))
+		}
+	}))
+	defer srv.Close()
+
+	method, err := config.NewPushoverMethod(config.PushoverConfig{
+		APIBaseURL: srv.URL,
+		Token:      "app",
+		UserKey:    "user",
+		Priority:   config.PushoverPriorityEmergency,
+		Retry:      60,
+		Expire:     3600,
+	})
+	if err != nil {
+		t.Fatalf("build pushover method: %v", err)
+	}
+	useSettings(t, method)
+	s := NewServer(config.Settings{}, nil)
+
+	text, isErr := callTool(t, s.handleNotifyTool, map[string]any{taskNameParam: "pending"})
+	if isErr || !strings.Contains(text, receiptParam+"=pending") {
+		t.Fatalf("notify result should carry the receipt: %q", text)
+	}
+
+	cases := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"acknowledged", map[string]any{receiptParam: "acked"}, "回执 acked 对应的紧急通知已于"},
+		{"expired", map[string]any{receiptParam: "expired"}, "已过期，用户未确认"},
+		{"pending by receipt", map[string]any{receiptParam: "pending"}, "尚未被确认"},
+		{"pending by task name", map[string]any{taskNameParam: "pending"}, "任务 pending 的紧急通知尚未被确认"},
+	}
+	for _, c := range cases {
+		text, isErr := callTool(t, s.handleAckStatusTool, c.args)
+		if isErr || !strings.Contains(text, c.want) {
+			t.Errorf("%s: got %q (error=%v), want %q", c.name, text, isErr, c.want)
+		}
+	}
+
+	if text, isErr := callTool(t, s.handleAckStatusTool, map[string]any{taskNameParam: "unknown"}); !isErr || !strings.Contains(text, receiptParam) {
+		t.Fatalf("expected error for unknown task, got %q", text)
+	}
+}
@@ -2,25 +2,59 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/zboyco/notify-mcp/internal/bark"
 	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/dingtalk"
+	"github.com/zboyco/notify-mcp/internal/discord"
+	"github.com/zboyco/notify-mcp/internal/email"
+	"github.com/zboyco/notify-mcp/internal/execnotify"
+	"github.com/zboyco/notify-mcp/internal/feishu"
+	"github.com/zboyco/notify-mcp/internal/googlechat"
+	"github.com/zboyco/notify-mcp/internal/gotify"
+	"github.com/zboyco/notify-mcp/internal/homeassistant"
+	"github.com/zboyco/notify-mcp/internal/lognotify"
+	"github.com/zboyco/notify-mcp/internal/matrix"
+	"github.com/zboyco/notify-mcp/internal/mattermost"
+	"github.com/zboyco/notify-mcp/internal/mqtt"
+	"github.com/zboyco/notify-mcp/internal/notify"
+	"github.com/zboyco/notify-mcp/internal/ntfy"
+	"github.com/zboyco/notify-mcp/internal/opsgenie"
 	"github.com/zboyco/notify-mcp/internal/osnotify"
+	"github.com/zboyco/notify-mcp/internal/pagerduty"
+	"github.com/zboyco/notify-mcp/internal/pushover"
+	"github.com/zboyco/notify-mcp/internal/pushplus"
+	"github.com/zboyco/notify-mcp/internal/rocketchat"
+	"github.com/zboyco/notify-mcp/internal/serverchan"
+	"github.com/zboyco/notify-mcp/internal/signalnotify"
+	"github.com/zboyco/notify-mcp/internal/slack"
+	"github.com/zboyco/notify-mcp/internal/syslognotify"
+	"github.com/zboyco/notify-mcp/internal/teams"
 	"github.com/zboyco/notify-mcp/internal/telegram"
+	"github.com/zboyco/notify-mcp/internal/terminal"
+	"github.com/zboyco/notify-mcp/internal/twilio"
+	"github.com/zboyco/notify-mcp/internal/webhook"
+	"github.com/zboyco/notify-mcp/internal/wecom"
 )
 
 const (
 	serverName      = "notify-mcp"
 	serverVersion   = "0.1.0"
 	toolName        = "notify"
+	ackToolName     = "notify_ack_status"
 	taskNameParam   = "taskName"
+	levelParam      = "level"
+	receiptParam    = "receipt"
 	defaultTaskName = "当前任务"
 )
 
@@ -29,6 +63,11 @@ type Server struct {
 	cfg       config.Settings
 	logger    *log.Logger
 	mcpServer *server.MCPServer
+
+	// receipts 记录各任务最近一次 Pushover 紧急通知的回执 ID，仅在调用方未传入回执时兜底使用；
+	// 同名任务会相互覆盖且进程重启后丢失，因此 notify 的结果中会直接返回回执 ID。
+	receiptsMu sync.Mutex
+	receipts   map[string]string
 }
 
 // NewServer builds a new MCP server backed by mark3labs/mcp-go.
@@ -48,6 +87,7 @@ func NewServer(cfg config.Settings, logger *log.Logger) *Server {
 		cfg:       cfg,
 		logger:    logger,
 		mcpServer: mcpServer,
+		receipts:  make(map[string]string),
 	}
 	s.registerTools()
 	return s
@@ -62,6 +102,11 @@ func (s *Server) Serve() error {
 }
 
 func (s *Server) registerTools() {
+	var levels []string
+	for _, level := range notify.Levels() {
+		levels = append(levels, string(level))
+	}
+
 	tool := mcp.NewTool(
 		toolName,
 		mcp.WithDescription("向已配置的渠道发送通知"),
@@ -70,11 +115,35 @@ func (s *Server) registerTools() {
 			mcp.Description("当前执行任务的缩略标题"),
 			mcp.DefaultString(defaultTaskName),
 		),
+		mcp.WithString(
+			levelParam,
+			mcp.Description("通知级别，任务成功使用 success，失败使用 error，需要立即人工介入时使用 critical"),
+			mcp.Enum(levels...),
+			mcp.DefaultString(string(notify.LevelInfo)),
+		),
 		mcp.WithTitleAnnotation("notify"),
 		mcp.WithDestructiveHintAnnotation(false),
 	)
 
 	s.mcpServer.AddTool(tool, s.handleNotifyTool)
+
+	ackTool := mcp.NewTool(
+		ackToolName,
+		mcp.WithDescription("查询 Pushover 紧急通知是否已被用户确认"),
+		mcp.WithString(
+			receiptParam,
+			mcp.Description("notify 结果中返回的 Pushover 回执 ID，推荐传入；未传入时按 taskName 查找本次会话中最近一次紧急通知"),
+		),
+		mcp.WithString(
+			taskNameParam,
+			mcp.Description("发送通知时使用的任务标题"),
+			mcp.DefaultString(defaultTaskName),
+		),
+		mcp.WithTitleAnnotation("notify ack status"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	)
+
+	s.mcpServer.AddTool(ackTool, s.handleAckStatusTool)
 }
 
 func (s *Server) handleNotifyTool(
@@ -82,6 +151,10 @@ func (s *Server) handleNotifyTool(
 	req mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
 	taskName := strings.TrimSpace(req.GetString(taskNameParam, defaultTaskName))
+	level := notify.Level(strings.TrimSpace(req.GetString(levelParam, string(notify.LevelInfo))))
+	if !level.Valid() {
+		return mcp.NewToolResultError(fmt.Sprintf("不支持的通知级别: %s", level)), nil
+	}
 	settings, err := config.Load()
 	if err != nil {
 		s.logger.Printf("重新加载配置失败: %v", err)
@@ -91,11 +164,20 @@ func (s *Server) handleNotifyTool(
 		s.logger.Println("配置中未包含通知方式")
 		return mcp.NewToolResultError("未配置任何通知方式"), nil
 	}
-	body := settings.EffectiveNotificationMessage()
-	message := fmt.Sprintf("时间：%s\n任务：%s\n%s", time.Now().Format("2006-01-02 15:04:05"), taskName, body)
+	msg := notify.Message{
+		Time:     time.Now(),
+		TaskName: taskName,
+		Body:     settings.EffectiveNotificationMessage(),
+		Level:    level,
+	}
+	message := msg.Text()
 
 	var successChannels []string
 	var failedChannels []string
+	var skippedChannels []string
+	var receipt string
+	// recipientFailures 记录按收件人发送的渠道中各收件人的失败明细，无论渠道整体成功与否都会返回给调用方。
+	var recipientFailures []string
 
 	for _, method := range settings.Methods {
 		var err error
@@ -108,10 +190,204 @@ func (s *Server) handleNotifyTool(
 			}
 		case config.MethodOS:
 			err = osnotify.Send(ctx, "AI通知助手", message)
+		case config.MethodSlack:
+			var slackCfg config.SlackConfig
+			slackCfg, err = method.SlackConfig()
+			if err == nil {
+				err = slack.SendMessage(ctx, slackCfg, msg)
+			}
+		case config.MethodDiscord:
+			var discordCfg config.DiscordConfig
+			discordCfg, err = method.DiscordConfig()
+			if err == nil {
+				err = discord.SendMessage(ctx, discordCfg, msg)
+			}
+		case config.MethodWeCom:
+			var wecomCfg config.WeComConfig
+			wecomCfg, err = method.WeComConfig()
+			if err == nil {
+				err = wecom.SendMessage(ctx, wecomCfg, msg)
+			}
+		case config.MethodDingTalk:
+			var dingtalkCfg config.DingTalkConfig
+			dingtalkCfg, err = method.DingTalkConfig()
+			if err == nil {
+				err = dingtalk.SendMessage(ctx, dingtalkCfg, msg)
+			}
+		case config.MethodFeishu:
+			var feishuCfg config.FeishuConfig
+			feishuCfg, err = method.FeishuConfig()
+			if err == nil {
+				err = feishu.SendMessage(ctx, feishuCfg, msg)
+			}
+		case config.MethodBark:
+			var barkCfg config.BarkConfig
+			barkCfg, err = method.BarkConfig()
+			if err == nil {
+				err = bark.SendMessage(ctx, barkCfg, msg)
+			}
+		case config.MethodNtfy:
+			var ntfyCfg config.NtfyConfig
+			ntfyCfg, err = method.NtfyConfig()
+			if err == nil {
+				err = ntfy.SendMessage(ctx, ntfyCfg, msg)
+			}
+		case config.MethodGotify:
+			var gotifyCfg config.GotifyConfig
+			gotifyCfg, err = method.GotifyConfig()
+			if err == nil {
+				err = gotify.SendMessage(ctx, gotifyCfg, msg)
+			}
+		case config.MethodPushover:
+			var pushoverCfg config.PushoverConfig
+			pushoverCfg, err = method.PushoverConfig()
+			if err == nil {
+				receipt, err = pushover.SendMessage(ctx, pushoverCfg, msg)
+			}
+			if receipt != "" {
+				s.recordReceipt(taskName, receipt)
+			}
+		case config.MethodEmail:
+			var emailCfg config.EmailConfig
+			emailCfg, err = method.EmailConfig()
+			if err == nil {
+				err = email.SendMessage(ctx, emailCfg, msg)
+			}
+		case config.MethodWebhook:
+			var webhookCfg config.WebhookConfig
+			webhookCfg, err = method.WebhookConfig()
+			if err == nil {
+				err = webhook.SendMessage(ctx, webhookCfg, msg)
+			}
+		case config.MethodTeams:
+			var teamsCfg config.TeamsConfig
+			teamsCfg, err = method.TeamsConfig()
+			if err == nil {
+				err = teams.SendMessage(ctx, teamsCfg, msg)
+			}
+		case config.MethodMatrix:
+			var matrixCfg config.MatrixConfig
+			matrixCfg, err = method.MatrixConfig()
+			if err == nil {
+				err = matrix.SendMessage(ctx, matrixCfg, msg)
+			}
+		case config.MethodMattermost:
+			var mattermostCfg config.MattermostConfig
+			mattermostCfg, err = method.MattermostConfig()
+			if err == nil {
+				err = mattermost.SendMessage(ctx, mattermostCfg, msg)
+			}
+		case config.MethodRocketChat:
+			var rocketchatCfg config.RocketChatConfig
+			rocketchatCfg, err = method.RocketChatConfig()
+			if err == nil {
+				err = rocketchat.SendMessage(ctx, rocketchatCfg, msg)
+			}
+		case config.MethodGoogleChat:
+			var googlechatCfg config.GoogleChatConfig
+			googlechatCfg, err = method.GoogleChatConfig()
+			if err == nil {
+				err = googlechat.SendMessage(ctx, googlechatCfg, msg)
+			}
+		case config.MethodServerChan:
+			var serverchanCfg config.ServerChanConfig
+			serverchanCfg, err = method.ServerChanConfig()
+			if err == nil {
+				err = serverchan.SendMessage(ctx, serverchanCfg, msg)
+			}
+		case config.MethodPushPlus:
+			var pushplusCfg config.PushPlusConfig
+			pushplusCfg, err = method.PushPlusConfig()
+			if err == nil {
+				err = pushplus.SendMessage(ctx, pushplusCfg, msg)
+			}
+		case config.MethodTerminal:
+			var terminalCfg config.TerminalConfig
+			terminalCfg, err = method.TerminalConfig()
+			if err == nil {
+				err = terminal.SendMessage(ctx, terminalCfg, msg)
+			}
+		case config.MethodExec:
+			var execCfg config.ExecConfig
+			execCfg, err = method.ExecConfig()
+			if err == nil {
+				err = execnotify.SendMessage(ctx, execCfg, msg)
+			}
+		case config.MethodLog:
+			var logCfg config.LogConfig
+			logCfg, err = method.LogConfig()
+			if err == nil {
+				err = lognotify.SendMessage(ctx, logCfg, msg)
+			}
+		case config.MethodSyslog:
+			var syslogCfg config.SyslogConfig
+			syslogCfg, err = method.SyslogConfig()
+			if err == nil {
+				err = syslognotify.SendMessage(ctx, syslogCfg, msg)
+			}
+		case config.MethodMQTT:
+			var mqttCfg config.MQTTConfig
+			mqttCfg, err = method.MQTTConfig()
+			if err == nil {
+				err = mqtt.SendMessage(ctx, mqttCfg, msg)
+			}
+		case config.MethodHomeAssistant:
+			var homeassistantCfg config.HomeAssistantConfig
+			homeassistantCfg, err = method.HomeAssistantConfig()
+			if err == nil {
+				err = homeassistant.SendMessage(ctx, homeassistantCfg, msg)
+			}
+		case config.MethodPagerDuty:
+			var pagerdutyCfg config.PagerDutyConfig
+			pagerdutyCfg, err = method.PagerDutyConfig()
+			if err == nil {
+				err = pagerduty.SendMessage(ctx, pagerdutyCfg, msg)
+			}
+		case config.MethodOpsgenie:
+			var opsgenieCfg config.OpsgenieConfig
+			opsgenieCfg, err = method.OpsgenieConfig()
+			if err == nil {
+				err = opsgenie.SendMessage(ctx, opsgenieCfg, msg)
+			}
+		case config.MethodTwilio:
+			var twilioCfg config.TwilioConfig
+			twilioCfg, err = method.TwilioConfig()
+			var failures []twilio.Failure
+			if err == nil {
+				failures, err = twilio.SendMessage(ctx, twilioCfg, msg)
+			}
+			items := make([]string, 0, len(failures))
+			for _, failure := range failures {
+				s.logger.Printf("通知方式 %s 发送给 %s 失败: %v", method.Type, failure.Recipient, failure.Err)
+				items = append(items, failure.String())
+			}
+			if len(items) > 0 {
+				recipientFailures = append(recipientFailures, fmt.Sprintf("Twilio 号码发送失败: %s", strings.Join(items, ", ")))
+			}
+		case config.MethodSignal:
+			var signalCfg config.SignalConfig
+			signalCfg, err = method.SignalConfig()
+			var failures []signalnotify.Failure
+			if err == nil {
+				failures, err = signalnotify.SendMessage(ctx, signalCfg, msg)
+			}
+			items := make([]string, 0, len(failures))
+			for _, failure := range failures {
+				s.logger.Printf("通知方式 %s 发送给 %s 失败: %v", method.Type, failure.Recipient, failure.Err)
+				items = append(items, failure.String())
+			}
+			if len(items) > 0 {
+				recipientFailures = append(recipientFailures, fmt.Sprintf("Signal 收件人发送失败: %s", strings.Join(items, ", ")))
+			}
 		default:
 			err = fmt.Errorf("未知通知方式: %s", method.Type)
 		}
 
+		if errors.Is(err, notify.ErrSkipped) {
+			s.logger.Printf("通知方式 %s 已跳过: %v", method.Type, err)
+			skippedChannels = append(skippedChannels, string(method.Type))
+			continue
+		}
 		if err != nil {
 			s.logger.Printf("通知方式 %s 发送失败: %v", method.Type, err)
 			failedChannels = append(failedChannels, string(method.Type))
@@ -122,13 +398,37 @@ func (s *Server) handleNotifyTool(
 		successChannels = append(successChannels, string(method.Type))
 	}
 
+	var skipped string
+	if len(skippedChannels) > 0 {
+		skipped = fmt.Sprintf("；已跳过渠道: %s", strings.Join(skippedChannels, ", "))
+	}
 	if len(successChannels) == 0 {
-		return mcp.NewToolResultError("所有通知方式均发送失败"), nil
+		if len(failedChannels) == 0 {
+			// 所有渠道都按配置跳过了本次通知，既不算成功也不算失败。
+			return mcp.NewToolResultText(fmt.Sprintf("未发送通知，所有渠道均已跳过: %s", strings.Join(skippedChannels, ", "))), nil
+		}
+		errMsg := "所有通知方式均发送失败"
+		if skipped != "" {
+			errMsg = fmt.Sprintf("通知发送失败，失败渠道: %s%s", strings.Join(failedChannels, ", "), skipped)
+		}
+		return mcp.NewToolResultError(withFailureDetails(errMsg, recipientFailures)), nil
 	}
 
 	resultMsg := fmt.Sprintf("通知成功，成功渠道: %s", strings.Join(successChannels, ", "))
 	if len(failedChannels) > 0 {
 		resultMsg = fmt.Sprintf("%s；失败渠道: %s", resultMsg, strings.Join(failedChannels, ", "))
 	}
-	return mcp.NewToolResultText(resultMsg), nil
+	resultMsg += skipped
+	if receipt != "" {
+		resultMsg = fmt.Sprintf("%s；Pushover 紧急通知回执: %s，可调用 %s 并传入 %s=%s 查询确认状态", resultMsg, receipt, ackToolName, receiptParam, receipt)
+	}
+	return mcp.NewToolResultText(withFailureDetails(resultMsg, recipientFailures)), nil
+}
+
+// withFailureDetails 将收件人级别的失败明细附加到结果消息后。
+func withFailureDetails(msg string, details []string) string {
+	for _, detail := range details {
+		msg = fmt.Sprintf("%s；%s", msg, detail)
+	}
+	return msg
 }
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+)
+
+func TestNotifyToolReportsSignalRecipientFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p struct {
+			Recipients []string `json:"recipients"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&p)
+		if len(p.Recipients) == 1 && p.Recipients[0] == "+15550000001" {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"Unregistered user"}`))
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		name       string
+		recipients []string
+		wantErr    bool
+		want       string
+	}{
+		{
+			name:       "partial failure",
+			recipients: []string{"+15550000001", "+15550000002"},
+			want:       "Signal 收件人发送失败: +15550000002（",
+		},
+		{
+			name:       "all recipients fail",
+			recipients: []string{"+15550000002", "+15550000003"},
+			wantErr:    true,
+			want:       "Signal 收件人发送失败: +15550000002（signal responded with 400 Bad Request: Unregistered user）, +15550000003（",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			method, err := config.NewSignalMethod(config.SignalConfig{
+				ServerURL:  srv.URL,
+				Number:     "+15551234567",
+				Recipients: c.recipients,
+			})
+			if err != nil {
+				t.Fatalf("build signal method: %v", err)
+			}
+			useSettings(t, method)
+			s := NewServer(config.Settings{}, nil)
+
+			text, isErr := callTool(t, s.handleNotifyTool, map[string]any{taskNameParam: "发布"})
+			if isErr != c.wantErr {
+				t.Fatalf("isError = %v, want %v: %q", isErr, c.wantErr, text)
+			}
+			if !strings.Contains(text, c.want) {
+				t.Fatalf("result should list failed recipients, got %q", text)
+			}
+		})
+	}
+}
+
+func TestNotifyToolReportsTwilioNumberFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("To") == "+15550000001" {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":21211,"message":"Invalid 'To' Phone Number"}`))
+	}))
+	defer srv.Close()
+
+	method, err := config.NewTwilioMethod(config.TwilioConfig{
+		APIBaseURL: srv.URL,
+		AccountSID: "AC123",
+		AuthToken:  "secret",
+		From:       "+15551234567",
+		To:         []string{"+15550000001", "+15550000002"},
+	})
+	if err != nil {
+		t.Fatalf("build twilio method: %v", err)
+	}
+	useSettings(t, method)
+	s := NewServer(config.Settings{}, nil)
+
+	text, isErr := callTool(t, s.handleNotifyTool, map[string]any{taskNameParam: "发布"})
+	if isErr || !strings.Contains(text, "成功渠道: twilio") || !strings.Contains(text, "Twilio 号码发送失败: +15550000002（send sms: ") {
+		t.Fatalf("unexpected result: %q", text)
+	}
+}
+
+func TestNotifyToolReportsSkippedChannels(t *testing.T) {
+	var events int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events++
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer srv.Close()
+
+	method, err := config.NewPagerDutyMethod(config.PagerDutyConfig{APIBaseURL: srv.URL, RoutingKey: "R0UT1NG"})
+	if err != nil {
+		t.Fatalf("build pagerduty method: %v", err)
+	}
+	useSettings(t, method)
+	s := NewServer(config.Settings{}, nil)
+
+	text, isErr := callTool(t, s.handleNotifyTool, map[string]any{taskNameParam: "备份", levelParam: "info"})
+	if isErr || strings.Contains(text, "通知成功") || !strings.Contains(text, "所有渠道均已跳过: pagerduty") {
+		t.Fatalf("below min level should be reported as skipped, got %q (isError=%v)", text, isErr)
+	}
+	if events != 0 {
+		t.Fatalf("expected no pagerduty request, got %d", events)
+	}
+
+	text, isErr = callTool(t, s.handleNotifyTool, map[string]any{taskNameParam: "备份", levelParam: "error"})
+	if isErr || !strings.Contains(text, "成功渠道: pagerduty") || strings.Contains(text, "跳过") {
+		t.Fatalf("unexpected result for error level: %q", text)
+	}
+}
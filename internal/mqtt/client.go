@@ -0,0 +1,242 @@
+// Package mqtt implements the small publish-only subset of MQTT 3.1.1 needed
+// to deliver one notification per connection: CONNECT, PUBLISH at QoS 0 or 1,
+// and DISCONNECT. A full client such as paho brings background goroutines,
+// reconnect logic and session stores that a connect-publish-disconnect flow
+// never uses, so the subset is kept in-tree. QoS 2 is deliberately not
+// supported; brokers deduplicating a notification is not worth its handshake.
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+const (
+	dialTimeout    = 15 * time.Second
+	sessionTimeout = 30 * time.Second
+	keepAlive      = 60
+	// publishID 是本次会话唯一一条 QoS>0 消息的报文标识符。
+	publishID uint16 = 1
+)
+
+// topicData 是主题模板可引用的字段。
+type topicData struct {
+	TaskName string
+	Level    string
+	Host     string
+}
+
+type payload struct {
+	TaskName  string `json:"taskName"`
+	Message   string `json:"message"`
+	Level     string `json:"level"`
+	Timestamp string `json:"timestamp"`
+	Host      string `json:"host"`
+}
+
+// SendMessage connects to the broker, publishes the notification as JSON and
+// disconnects, waiting for the PUBACK when QoS is 1.
+func SendMessage(ctx context.Context, cfg config.MQTTConfig, msg notify.Message) error {
+	host, _ := os.Hostname()
+	topic, err := renderTopic(cfg, topicData{TaskName: msg.TaskName, Level: string(msg.Level), Host: host})
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload{
+		TaskName:  msg.TaskName,
+		Message:   msg.Body,
+		Level:     string(msg.Level),
+		Timestamp: msg.Time.Format(time.RFC3339),
+		Host:      host,
+	})
+	if err != nil {
+		return fmt.Errorf("encode mqtt payload: %w", err)
+	}
+
+	conn, err := dial(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// 工具调用被取消时立即断开连接，中止阻塞中的读写。
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	r := bufio.NewReader(conn)
+	if err := connect(conn, r, cfg); err != nil {
+		return err
+	}
+	if err := publish(conn, r, topic, cfg.QoS, cfg.Retain, body); err != nil {
+		return err
+	}
+	if err := writePacket(conn, packetDisconnect, 0, nil); err != nil {
+		return fmt.Errorf("mqtt disconnect: %w", err)
+	}
+	return nil
+}
+
+// dial 建立到 broker 的 TCP 或 TLS 连接，连接受 ctx 控制。
+func dial(ctx context.Context, cfg config.MQTTConfig) (net.Conn, error) {
+	u, err := url.Parse(cfg.BrokerURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse mqtt broker url: %w", err)
+	}
+	useTLS := cfg.UseTLS()
+	port := u.Port()
+	if port == "" {
+		port = "1883"
+		if useTLS {
+			port = "8883"
+		}
+	}
+	addr := net.JoinHostPort(u.Hostname(), port)
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	var conn net.Conn
+	if useTLS {
+		tc, err := tlsConfig(cfg, u.Hostname())
+		if err != nil {
+			return nil, err
+		}
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tc}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("connect mqtt broker: %w", err)
+		}
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("connect mqtt broker: %w", err)
+		}
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(sessionTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("set mqtt deadline: %w", err)
+	}
+	return conn, nil
+}
+
+func tlsConfig(cfg config.MQTTConfig, serverName string) (*tls.Config, error) {
+	tc := &tls.Config{ServerName: serverName}
+	if cfg.CAFile == "" {
+		return tc, nil
+	}
+	pem, err := os.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read mqtt ca file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in mqtt ca file %s", cfg.CAFile)
+	}
+	tc.RootCAs = pool
+	return tc, nil
+}
+
+func connect(conn net.Conn, r *bufio.Reader, cfg config.MQTTConfig) error {
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = randomClientID()
+	}
+	body, err := connectBody(clientID, cfg.Username, cfg.Password, keepAlive)
+	if err != nil {
+		return err
+	}
+	if err := writePacket(conn, packetConnect, 0, body); err != nil {
+		return fmt.Errorf("mqtt connect: %w", err)
+	}
+
+	ack, err := readPacket(r)
+	if err != nil {
+		return fmt.Errorf("read mqtt connack: %w", err)
+	}
+	if ack.kind != packetConnAck || len(ack.body) != 2 {
+		return fmt.Errorf("unexpected mqtt packet type %d, want connack", ack.kind)
+	}
+	if code := ack.body[1]; code != 0 {
+		reason, ok := connAckErrors[code]
+		if !ok {
+			reason = "unknown error"
+		}
+		return fmt.Errorf("mqtt broker refused connection: %s (code %d)", reason, code)
+	}
+	return nil
+}
+
+// publish 发送 PUBLISH，QoS 1 时等待 PUBACK 确认。
+func publish(conn net.Conn, r *bufio.Reader, topic string, qos int, retain bool, body []byte) error {
+	packetBody, err := publishBody(topic, qos, publishID, body)
+	if err != nil {
+		return err
+	}
+	if err := writePacket(conn, packetPublish, publishFlags(qos, retain), packetBody); err != nil {
+		return fmt.Errorf("mqtt publish: %w", err)
+	}
+
+	if qos == 1 {
+		return expectAck(r, packetPubAck)
+	}
+	return nil
+}
+
+func expectAck(r *bufio.Reader, kind byte) error {
+	p, err := readPacket(r)
+	if err != nil {
+		return fmt.Errorf("read mqtt ack: %w", err)
+	}
+	if p.kind != kind {
+		return fmt.Errorf("unexpected mqtt packet type %d, want %d", p.kind, kind)
+	}
+	id, err := p.packetID()
+	if err != nil {
+		return err
+	}
+	if id != publishID {
+		return fmt.Errorf("unexpected mqtt packet id %d", id)
+	}
+	return nil
+}
+
+func renderTopic(cfg config.MQTTConfig, data topicData) (string, error) {
+	tmpl, err := cfg.TopicTemplate()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render mqtt topic: %w", err)
+	}
+	topic := buf.String()
+	// 发布主题不能为空，也不能包含通配符。
+	if topic == "" || strings.ContainsAny(topic, "+#\x00") {
+		return "", fmt.Errorf("invalid mqtt topic %q", topic)
+	}
+	return topic, nil
+}
+
+func randomClientID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "notify-mcp"
+	}
+	return "notify-mcp-" + hex.EncodeToString(b)
+}
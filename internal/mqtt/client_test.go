@@ -0,0 +1,148 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type published struct {
+	clientID string
+	username string
+	topic    string
+	flags    byte
+	payload  map[string]string
+}
+
+// startBroker 启动一个只处理单个连接的最小 MQTT broker，connAckCode 非 0 时拒绝连接。
+func startBroker(t *testing.T, connAckCode byte) (string, <-chan published) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	result := make(chan published, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+
+		var got published
+		p, err := readPacket(r)
+		if err != nil || p.kind != packetConnect {
+			t.Errorf("expected connect, got %+v %v", p, err)
+			return
+		}
+		// 跳过协议名（6 字节）、协议级别、连接标志与 keep alive。
+		rest := p.body[10:]
+		got.clientID, rest = readString(rest)
+		if p.body[7]&0x80 != 0 {
+			got.username, _ = readString(rest)
+		}
+		_ = writePacket(conn, packetConnAck, 0, []byte{0, connAckCode})
+		if connAckCode != 0 {
+			return
+		}
+
+		p, err = readPacket(r)
+		if err != nil || p.kind != packetPublish {
+			t.Errorf("expected publish, got %+v %v", p, err)
+			return
+		}
+		got.flags = p.flags
+		got.topic, rest = readString(p.body)
+		qos := (p.flags >> 1) & 0x03
+		if qos == 1 {
+			_ = writePacket(conn, packetPubAck, 0, rest[:2])
+			rest = rest[2:]
+		}
+		if err := json.Unmarshal(rest, &got.payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		if p, err := readPacket(r); err != nil || p.kind != packetDisconnect {
+			t.Errorf("expected disconnect, got %+v %v", p, err)
+		}
+		result <- got
+	}()
+	return "mqtt://" + ln.Addr().String(), result
+}
+
+func readString(b []byte) (string, []byte) {
+	n := binary.BigEndian.Uint16(b)
+	return string(b[2 : 2+n]), b[2+n:]
+}
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	msg := notify.Message{Time: time.Now(), TaskName: "部署", Body: "完成", Level: notify.LevelSuccess}
+	for _, qos := range []int{0, 1} {
+		brokerURL, result := startBroker(t, 0)
+		cfg := config.MQTTConfig{
+			BrokerURL: brokerURL,
+			Username:  "lamp",
+			Password:  "secret",
+			ClientID:  "desk",
+			Topic:     "agents/{{.Level}}",
+			QoS:       qos,
+			Retain:    true,
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := SendMessage(ctx, cfg, msg)
+		cancel()
+		if err != nil {
+			t.Fatalf("qos %d: SendMessage returned error: %v", qos, err)
+		}
+
+		got := <-result
+		if got.clientID != "desk" || got.username != "lamp" || got.topic != "agents/success" {
+			t.Fatalf("qos %d: unexpected session: %+v", qos, got)
+		}
+		if got.flags != publishFlags(qos, true) {
+			t.Fatalf("qos %d: unexpected publish flags %04b", qos, got.flags)
+		}
+		if got.payload["taskName"] != "部署" || got.payload["message"] != "完成" || got.payload["level"] != "success" {
+			t.Fatalf("qos %d: unexpected payload: %v", qos, got.payload)
+		}
+	}
+}
+
+func TestSendMessageRefused(t *testing.T) {
+	t.Parallel()
+
+	brokerURL, _ := startBroker(t, 5)
+	cfg := config.MQTTConfig{BrokerURL: brokerURL, Topic: "agents"}
+	msg := notify.Message{Time: time.Now(), TaskName: "部署", Level: notify.LevelInfo}
+	if err := SendMessage(context.Background(), cfg, msg); err == nil {
+		t.Fatal("expected error for refused connection")
+	}
+}
+
+func TestOversizeStringsRejected(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("a", maxStringLength+1)
+	if _, err := publishBody(long, 0, publishID, nil); err == nil || !strings.Contains(err.Error(), "topic too long") {
+		t.Fatalf("expected topic length error, got %v", err)
+	}
+	if _, err := connectBody("desk", "lamp", long, keepAlive); err == nil || !strings.Contains(err.Error(), "password too long") {
+		t.Fatalf("expected password length error, got %v", err)
+	}
+	if _, err := connectBody(strings.Repeat("a", maxStringLength), "", "", keepAlive); err != nil {
+		t.Fatalf("max length client id should be accepted: %v", err)
+	}
+}
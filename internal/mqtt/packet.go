@@ -0,0 +1,156 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MQTT 3.1.1 控制报文类型（高 4 位）。
+const (
+	packetConnect    byte = 1
+	packetConnAck    byte = 2
+	packetPublish    byte = 3
+	packetPubAck     byte = 4
+	packetDisconnect byte = 14
+)
+
+const (
+	// maxRemainingLength 是剩余长度字段可表示的最大值（4 字节变长编码）。
+	maxRemainingLength = 268435455
+	// maxStringLength 是 UTF-8 字符串 2 字节长度前缀可表示的最大值。
+	maxStringLength = 65535
+)
+
+// packet 是一个已解析固定报头的控制报文。
+type packet struct {
+	kind  byte
+	flags byte
+	body  []byte
+}
+
+// connAckErrors 对应 CONNACK 中非 0 的返回码。
+var connAckErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+func writePacket(w io.Writer, kind, flags byte, body []byte) error {
+	if len(body) > maxRemainingLength {
+		return fmt.Errorf("mqtt packet too large: %d bytes", len(body))
+	}
+	buf := make([]byte, 0, len(body)+5)
+	buf = append(buf, kind<<4|flags)
+	n := len(body)
+	for {
+		b := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			b |= 0x80
+		}
+		buf = append(buf, b)
+		if n == 0 {
+			break
+		}
+	}
+	buf = append(buf, body...)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readPacket(r *bufio.Reader) (packet, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return packet{}, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return packet{}, errors.New("malformed mqtt remaining length")
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return packet{}, err
+		}
+		length += int(b&0x7f) * multiplier
+		if b&0x80 == 0 {
+			break
+		}
+		multiplier *= 128
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return packet{}, err
+	}
+	return packet{kind: header >> 4, flags: header & 0x0f, body: body}, nil
+}
+
+// appendString 以 2 字节长度前缀写入字符串，超长时返回错误而不是截断长度导致报文错位。
+func appendString(buf []byte, name, s string) ([]byte, error) {
+	if len(s) > maxStringLength {
+		return nil, fmt.Errorf("mqtt %s too long: %d bytes, max %d", name, len(s), maxStringLength)
+	}
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(s)))
+	return append(buf, s...), nil
+}
+
+func connectBody(clientID, username, password string, keepAlive uint16) ([]byte, error) {
+	// 固定使用 clean session，通知发送完即断开，不需要保留会话状态。
+	flags := byte(0x02)
+	if username != "" {
+		flags |= 0x80
+		if password != "" {
+			flags |= 0x40
+		}
+	}
+
+	body := []byte{0, 4, 'M', 'Q', 'T', 'T', 4, flags}
+	body = binary.BigEndian.AppendUint16(body, keepAlive)
+	body, err := appendString(body, "client id", clientID)
+	if err != nil {
+		return nil, err
+	}
+	if username != "" {
+		if body, err = appendString(body, "username", username); err != nil {
+			return nil, err
+		}
+		if password != "" {
+			if body, err = appendString(body, "password", password); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return body, nil
+}
+
+func publishFlags(qos int, retain bool) byte {
+	flags := byte(qos) << 1
+	if retain {
+		flags |= 0x01
+	}
+	return flags
+}
+
+func publishBody(topic string, qos int, packetID uint16, payload []byte) ([]byte, error) {
+	body, err := appendString(nil, "topic", topic)
+	if err != nil {
+		return nil, err
+	}
+	if qos > 0 {
+		body = binary.BigEndian.AppendUint16(body, packetID)
+	}
+	return append(body, payload...), nil
+}
+
+// packetID 读取 PUBACK 报文中的报文标识符。
+func (p packet) packetID() (uint16, error) {
+	if len(p.body) < 2 {
+		return 0, errors.New("malformed mqtt ack packet")
+	}
+	return binary.BigEndian.Uint16(p.body), nil
+}
@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrSkipped reports that a channel deliberately did not send the notification
+// because of its own settings. Callers should not count it as a failure.
+var ErrSkipped = errors.New("notification skipped")
+
+// TimeLayout is the layout used to display the notification time.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// Message is the structured content of a notification; each channel renders
+// it in its own format.
+type Message struct {
+	Time     time.Time
+	TaskName string
+	Body     string
+	Level    Level
+}
+
+// FormattedTime returns the notification time formatted with TimeLayout.
+func (m Message) FormattedTime() string {
+	return m.Time.Format(TimeLayout)
+}
+
+// Text renders the notification as plain text for channels without rich formatting.
+func (m Message) Text() string {
+	return fmt.Sprintf("时间：%s\n任务：%s\n%s", m.FormattedTime(), m.TaskName, m.Body)
+}
+
+// Level is the severity of a notification; some channels adjust styling by it.
+type Level string
+
+const (
+	LevelInfo    Level = "info"
+	LevelSuccess Level = "success"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+	// LevelCritical needs immediate human attention; some channels escalate it.
+	LevelCritical Level = "critical"
+)
+
+// Levels returns all supported notification levels.
+func Levels() []Level {
+	return []Level{LevelInfo, LevelSuccess, LevelWarning, LevelError, LevelCritical}
+}
+
+// severityOrder 为告警类级别从低到高的顺序，success 不参与比较。
+var severityOrder = []Level{LevelInfo, LevelWarning, LevelError, LevelCritical}
+
+// AtLeast reports whether the level is at or above min in the order
+// info < warning < error < critical. It is always false for success and
+// unknown levels.
+func (l Level) AtLeast(min Level) bool {
+	i, j := slices.Index(severityOrder, l), slices.Index(severityOrder, min)
+	return i >= 0 && j >= 0 && i >= j
+}
+
+// Valid reports whether the level is supported.
+func (l Level) Valid() bool {
+	for _, item := range Levels() {
+		if l == item {
+			return true
+		}
+	}
+	return false
+}
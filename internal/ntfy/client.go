@@ -0,0 +1,108 @@
+package ntfy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+// levelTags 为不同通知级别附加 emoji 标签，便于在通知列表中区分。
+var levelTags = map[notify.Level]string{
+	notify.LevelSuccess:  "white_check_mark",
+	notify.LevelWarning:  "warning",
+	notify.LevelError:    "rotating_light",
+	notify.LevelCritical: "sos",
+}
+
+type action struct {
+	Action string `json:"action"`
+	Label  string `json:"label"`
+	URL    string `json:"url"`
+}
+
+type payload struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title"`
+	Message  string   `json:"message"`
+	Priority int      `json:"priority,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Click    string   `json:"click,omitempty"`
+	Actions  []action `json:"actions,omitempty"`
+	Markdown bool     `json:"markdown"`
+}
+
+// SendMessage publishes the notification to the configured ntfy topic via the JSON API.
+func SendMessage(ctx context.Context, cfg config.NtfyConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode ntfy payload: %w", err)
+	}
+
+	// JSON 发布接口要求请求发往服务根路径，topic 放在请求体中。
+	endpoint := strings.TrimRight(cfg.ServerURL, "/") + "/"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build ntfy request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch {
+	case cfg.Token != "":
+		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	case cfg.Username != "":
+		req.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call ntfy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("ntfy responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func buildPayload(cfg config.NtfyConfig, msg notify.Message) payload {
+	tags := append([]string(nil), cfg.Tags...)
+	if tag, ok := levelTags[msg.Level]; ok {
+		tags = append(tags, tag)
+	}
+
+	priority := cfg.Priority
+	if priority == 0 {
+		switch msg.Level {
+		case notify.LevelError:
+			priority = 4
+		case notify.LevelCritical:
+			priority = 5
+		}
+	}
+
+	var actions []action
+	for _, item := range cfg.Actions {
+		actions = append(actions, action{Action: "view", Label: item.Label, URL: item.URL})
+	}
+
+	return payload{
+		Topic:    cfg.Topic,
+		Title:    msg.TaskName,
+		Message:  fmt.Sprintf("%s\n\n时间：%s", msg.Body, msg.FormattedTime()),
+		Priority: priority,
+		Tags:     tags,
+		Click:    cfg.ClickURL,
+		Actions:  actions,
+		Markdown: true,
+	}
+}
@@ -0,0 +1,82 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		got  payload
+		auth string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		if got.Topic == "forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"code":40301,"http":403,"error":"forbidden"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"abc"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.NtfyConfig{
+		ServerURL: srv.URL,
+		Topic:     "alerts",
+		Token:     "tk_123",
+		Tags:      []string{"robot"},
+		ClickURL:  "https://ci.example.com",
+		Actions:   []config.NtfyAction{{Label: "日志", URL: "https://ci.example.com/log"}},
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "失败", Level: notify.LevelError}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if auth != "Bearer tk_123" {
+		t.Fatalf("unexpected authorization header %q", auth)
+	}
+	// 未配置优先级时 error 级别提升为 4，并附加级别标签。
+	if got.Priority != 4 || strings.Join(got.Tags, ",") != "robot,rotating_light" {
+		t.Fatalf("unexpected priority/tags: %d %v", got.Priority, got.Tags)
+	}
+	if got.Click != cfg.ClickURL || len(got.Actions) != 1 || got.Actions[0] != (action{Action: "view", Label: "日志", URL: "https://ci.example.com/log"}) {
+		t.Fatalf("unexpected click/actions: %+v", got)
+	}
+
+	cfg.Token = ""
+	cfg.Username, cfg.Password = "user", "pass"
+	cfg.Priority = 2
+	msg.Level = notify.LevelCritical
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if !strings.HasPrefix(auth, "Basic ") {
+		t.Fatalf("expected basic auth, got %q", auth)
+	}
+	if got.Priority != 2 {
+		t.Fatalf("configured priority should win, got %d", got.Priority)
+	}
+
+	cfg.Topic = "forbidden"
+	err := SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("expected 403 error, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package opsgenie
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+// maxMessage 是告警 message 字段的长度上限。
+const maxMessage = 130
+
+type responder struct {
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+type createRequest struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description"`
+	Responders  []responder       `json:"responders,omitempty"`
+	Priority    string            `json:"priority,omitempty"`
+	Source      string            `json:"source"`
+	Tags        []string          `json:"tags"`
+	Details     map[string]string `json:"details"`
+}
+
+type closeRequest struct {
+	Source string `json:"source"`
+	Note   string `json:"note"`
+}
+
+type response struct {
+	Message string `json:"message"`
+}
+
+// SendMessage creates an Opsgenie alert for the task when the level reaches the
+// configured minimum, or closes it when the level is success. Alerts for the
+// same task share an alias so repeats are merged. Lower levels are not sent
+// and return an error wrapping notify.ErrSkipped.
+func SendMessage(ctx context.Context, cfg config.OpsgenieConfig, msg notify.Message) error {
+	if msg.Level != notify.LevelSuccess && !msg.Level.AtLeast(notify.Level(cfg.TriggerLevel())) {
+		return fmt.Errorf("level %s is below opsgenie min level %s: %w", msg.Level, cfg.TriggerLevel(), notify.ErrSkipped)
+	}
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "notify-mcp"
+	}
+	base := strings.TrimRight(cfg.APIBaseURL, "/")
+	alias := alertAlias(msg.TaskName)
+
+	var (
+		endpoint string
+		body     any
+	)
+	if msg.Level == notify.LevelSuccess {
+		endpoint = fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", base, alias)
+		body = closeRequest{Source: host, Note: msg.Body}
+	} else {
+		endpoint = base + "/v2/alerts"
+		body = buildCreateRequest(cfg, msg, alias, host)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode opsgenie request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("build opsgenie request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+cfg.APIKey)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call opsgenie: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Opsgenie 异步处理告警请求，成功时返回 202。
+	if resp.StatusCode >= 300 {
+		var result response
+		if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && result.Message != "" {
+			return fmt.Errorf("opsgenie responded with %s: %s", resp.Status, result.Message)
+		}
+		return fmt.Errorf("opsgenie responded with %s", resp.Status)
+	}
+	return nil
+}
+
+func buildCreateRequest(cfg config.OpsgenieConfig, msg notify.Message, alias, host string) createRequest {
+	var responders []responder
+	for _, r := range cfg.Responders {
+		if r.Type == "user" {
+			responders = append(responders, responder{Type: r.Type, Username: r.Name})
+			continue
+		}
+		responders = append(responders, responder{Type: r.Type, Name: r.Name})
+	}
+
+	return createRequest{
+		Message:     truncate(msg.TaskName, maxMessage),
+		Alias:       alias,
+		Description: msg.Text(),
+		Responders:  responders,
+		Priority:    cfg.Priority,
+		Source:      host,
+		Tags:        []string{"notify-mcp", string(msg.Level)},
+		Details: map[string]string{
+			"task":    msg.TaskName,
+			"message": msg.Body,
+			"level":   string(msg.Level),
+			"time":    msg.FormattedTime(),
+		},
+	}
+}
+
+// alertAlias 根据任务名称生成稳定的告警 alias，哈希后不含斜杠等需要转义的字符，
+// 可以直接拼入关闭告警的 URL 路径。
+func alertAlias(taskName string) string {
+	sum := sha256.Sum256([]byte(taskName))
+	return "notify-mcp-" + hex.EncodeToString(sum[:16])
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,148 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type recorded struct {
+	rawPath string
+	query   string
+	body    map[string]any
+}
+
+func newServer(t *testing.T, requests *[]recorded) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		if r.Header.Get("Authorization") != "GenieKey k3y" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Key format is not valid!"}`))
+			return
+		}
+		*requests = append(*requests, recorded{
+			rawPath: r.URL.EscapedPath(),
+			query:   r.URL.RawQuery,
+			body:    body,
+		})
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"result":"Request will be processed","requestId":"1"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSendMessageCreateAndClose(t *testing.T) {
+	t.Parallel()
+
+	var requests []recorded
+	srv := newServer(t, &requests)
+	cfg := config.OpsgenieConfig{
+		APIBaseURL: srv.URL,
+		APIKey:     "k3y",
+		Responders: []config.OpsgenieResponder{{Type: "team", Name: "platform"}, {Type: "user", Name: "alice@example.com"}},
+		Priority:   "P2",
+	}
+	// 任务名包含斜杠，关闭告警时 alias 不能被转义成 %2F。
+	task := "deploy/prod 迁移"
+	failed := notify.Message{Time: time.Now(), TaskName: task, Body: "卡住了", Level: notify.LevelError}
+	if err := SendMessage(context.Background(), cfg, failed); err != nil {
+		t.Fatalf("create returned error: %v", err)
+	}
+	done := notify.Message{Time: time.Now(), TaskName: task, Body: "完成", Level: notify.LevelSuccess}
+	if err := SendMessage(context.Background(), cfg, done); err != nil {
+		t.Fatalf("close returned error: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	create, closing := requests[0], requests[1]
+	if create.rawPath != "/v2/alerts" {
+		t.Fatalf("unexpected create path %s", create.rawPath)
+	}
+	alias, _ := create.body["alias"].(string)
+	if alias == "" || strings.ContainsAny(alias, "/% ") {
+		t.Fatalf("alias should be path safe, got %q", alias)
+	}
+	if create.body["priority"] != "P2" || create.body["message"] != task {
+		t.Fatalf("unexpected create body: %v", create.body)
+	}
+	responders, _ := create.body["responders"].([]any)
+	if len(responders) != 2 || responders[1].(map[string]any)["username"] != "alice@example.com" {
+		t.Fatalf("unexpected responders: %v", create.body["responders"])
+	}
+
+	if want := "/v2/alerts/" + alias + "/close"; closing.rawPath != want {
+		t.Fatalf("close path = %s, want %s", closing.rawPath, want)
+	}
+	if closing.query != "identifierType=alias" {
+		t.Fatalf("unexpected close query %q", closing.query)
+	}
+	if closing.body["note"] != "完成" {
+		t.Fatalf("unexpected close body: %v", closing.body)
+	}
+}
+
+func TestSendMessageMinLevel(t *testing.T) {
+	t.Parallel()
+
+	var requests []recorded
+	srv := newServer(t, &requests)
+	cfg := config.OpsgenieConfig{APIBaseURL: srv.URL, APIKey: "k3y"}
+
+	for _, level := range []notify.Level{notify.LevelInfo, notify.LevelWarning, notify.LevelError, notify.LevelCritical} {
+		msg := notify.Message{Time: time.Now(), TaskName: "备份", Body: "内容", Level: level}
+		err := SendMessage(context.Background(), cfg, msg)
+		if skip := level == notify.LevelInfo || level == notify.LevelWarning; skip != errors.Is(err, notify.ErrSkipped) {
+			t.Fatalf("level %s: skip = %v, got error %v", level, skip, err)
+		}
+		if err != nil && !errors.Is(err, notify.ErrSkipped) {
+			t.Fatalf("level %s returned error: %v", level, err)
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected only error and critical to create alerts, got %d requests", len(requests))
+	}
+	for i, level := range []string{"error", "critical"} {
+		tags, _ := requests[i].body["tags"].([]any)
+		if len(tags) != 2 || tags[1] != level {
+			t.Fatalf("request %d: unexpected tags %v", i, tags)
+		}
+	}
+
+	requests = nil
+	cfg.MinLevel = "info"
+	msg := notify.Message{Time: time.Now(), TaskName: "备份", Body: "进度", Level: notify.LevelInfo}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("info returned error: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("expected info to create an alert with min level info, got %d requests", len(requests))
+	}
+}
+
+func TestSendMessageError(t *testing.T) {
+	t.Parallel()
+
+	var requests []recorded
+	srv := newServer(t, &requests)
+	cfg := config.OpsgenieConfig{APIBaseURL: srv.URL, APIKey: "wrong"}
+	msg := notify.Message{Time: time.Now(), TaskName: "备份", Body: "失败", Level: notify.LevelCritical}
+	err := SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "Key format is not valid") {
+		t.Fatalf("expected api error, got %v", err)
+	}
+}
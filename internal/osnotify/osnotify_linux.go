@@ -0,0 +1,109 @@
+//go:build linux
+
+package osnotify
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	dbusAppName   = "notify-mcp"
+	dbusDest      = "org.freedesktop.Notifications"
+	dbusPath      = dbus.ObjectPath("/org/freedesktop/Notifications")
+	dbusNotifyMtd = dbusDest + ".Notify"
+)
+
+// Urgency is the urgency hint defined by the freedesktop notification spec.
+type Urgency byte
+
+const (
+	UrgencyLow      Urgency = 0
+	UrgencyNormal   Urgency = 1
+	UrgencyCritical Urgency = 2
+)
+
+// ExpireNever keeps the notification on screen until the user dismisses it.
+const ExpireNever time.Duration = -1
+
+// Notification describes a desktop notification sent over D-Bus.
+type Notification struct {
+	Title   string
+	Body    string
+	Urgency Urgency
+	// ExpireTimeout 为 0 时使用通知服务的默认时长，ExpireNever 表示常驻。
+	ExpireTimeout time.Duration
+	// ReplacesID 非 0 时替换之前 Push 返回的同 ID 通知。
+	ReplacesID uint32
+}
+
+// Send shows a desktop notification with normal urgency.
+func Send(ctx context.Context, title, message string) error {
+	_, err := Push(ctx, Notification{
+		Title:   title,
+		Body:    message,
+		Urgency: UrgencyNormal,
+	})
+	return err
+}
+
+// Push sends the notification over the session bus and returns the ID
+// assigned by the notification server.
+func Push(ctx context.Context, n Notification) (uint32, error) {
+	conn, err := dbus.ConnectSessionBus(dbus.WithContext(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("连接 D-Bus 会话总线失败: %w", err)
+	}
+	defer conn.Close()
+
+	return notify(ctx, conn, n)
+}
+
+func notify(ctx context.Context, conn *dbus.Conn, n Notification) (uint32, error) {
+	iconPath, err := ensurePNGPath()
+	if err != nil {
+		return 0, err
+	}
+
+	hints := map[string]dbus.Variant{
+		"urgency":    dbus.MakeVariant(byte(n.Urgency)),
+		"image-path": dbus.MakeVariant("file://" + iconPath),
+	}
+
+	var id uint32
+	call := conn.Object(dbusDest, dbusPath).CallWithContext(
+		ctx,
+		dbusNotifyMtd,
+		0,
+		dbusAppName,
+		n.ReplacesID,
+		iconPath,
+		n.Title,
+		n.Body,
+		[]string{},
+		hints,
+		expireMillis(n.ExpireTimeout),
+	)
+	if err := call.Store(&id); err != nil {
+		return 0, fmt.Errorf("调用 %s 失败: %w", dbusNotifyMtd, err)
+	}
+	return id, nil
+}
+
+// expireMillis 将超时时长转换为 D-Bus 协议要求的 int32 毫秒数，超出范围时截断为最大值。
+func expireMillis(d time.Duration) int32 {
+	switch {
+	case d == ExpireNever:
+		return 0
+	case d <= 0:
+		return -1
+	case d.Milliseconds() > math.MaxInt32:
+		return math.MaxInt32
+	default:
+		return int32(d.Milliseconds())
+	}
+}
@@ -0,0 +1,186 @@
+//go:build linux
+
+package osnotify
+
+import (
+	"bufio"
+	"context"
+	"math"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+type fakeNotification struct {
+	appName    string
+	replacesID uint32
+	appIcon    string
+	summary    string
+	body       string
+	hints      map[string]dbus.Variant
+	expire     int32
+}
+
+type fakeNotificationServer struct {
+	mu     sync.Mutex
+	nextID uint32
+	calls  []fakeNotification
+}
+
+func (s *fakeNotificationServer) Notify(
+	appName string,
+	replacesID uint32,
+	appIcon, summary, body string,
+	_ []string,
+	hints map[string]dbus.Variant,
+	expire int32,
+) (uint32, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, fakeNotification{
+		appName:    appName,
+		replacesID: replacesID,
+		appIcon:    appIcon,
+		summary:    summary,
+		body:       body,
+		hints:      hints,
+		expire:     expire,
+	})
+	if replacesID != 0 {
+		return replacesID, nil
+	}
+	s.nextID++
+	return s.nextID, nil
+}
+
+// startPrivateBus 启动一个独立的 dbus-daemon，避免依赖宿主机的会话总线。
+func startPrivateBus(t *testing.T) string {
+	t.Helper()
+
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("未找到 dbus-daemon，跳过 D-Bus 测试")
+	}
+
+	cmd := exec.Command(daemon, "--session", "--nofork", "--print-address")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("创建 dbus-daemon 输出管道失败: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("启动 dbus-daemon 失败: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	addr, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("读取 dbus-daemon 地址失败: %v", err)
+	}
+	return strings.TrimSpace(addr)
+}
+
+func connectPrivateBus(t *testing.T, addr string) *dbus.Conn {
+	t.Helper()
+
+	conn, err := dbus.Connect(addr)
+	if err != nil {
+		t.Fatalf("连接私有总线失败: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestNotifyOverPrivateBus(t *testing.T) {
+	addr := startPrivateBus(t)
+
+	serverConn := connectPrivateBus(t, addr)
+	fake := &fakeNotificationServer{}
+	if err := serverConn.Export(fake, dbusPath, dbusDest); err != nil {
+		t.Fatalf("导出通知服务失败: %v", err)
+	}
+	reply, err := serverConn.RequestName(dbusDest, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("注册通知服务名失败: reply=%v err=%v", reply, err)
+	}
+
+	clientConn := connectPrivateBus(t, addr)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	id, err := notify(ctx, clientConn, Notification{
+		Title:         "Notify MCP",
+		Body:          "Triggered from go test",
+		Urgency:       UrgencyCritical,
+		ExpireTimeout: 3 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("notify returned error: %v", err)
+	}
+	if id == 0 {
+		t.Fatal("expected non-zero notification id")
+	}
+
+	replacedID, err := notify(ctx, clientConn, Notification{
+		Title:         "Notify MCP",
+		Body:          "Replaced",
+		ExpireTimeout: ExpireNever,
+		ReplacesID:    id,
+	})
+	if err != nil {
+		t.Fatalf("notify with replaces id returned error: %v", err)
+	}
+	if replacedID != id {
+		t.Fatalf("expected replaced id %d, got %d", id, replacedID)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	if len(fake.calls) != 2 {
+		t.Fatalf("expected 2 notify calls, got %d", len(fake.calls))
+	}
+
+	first := fake.calls[0]
+	if first.appName != dbusAppName || first.summary != "Notify MCP" || first.body != "Triggered from go test" {
+		t.Fatalf("unexpected notification: %+v", first)
+	}
+	if first.appIcon == "" {
+		t.Fatal("expected app icon path")
+	}
+	if urgency, ok := first.hints["urgency"].Value().(byte); !ok || Urgency(urgency) != UrgencyCritical {
+		t.Fatalf("unexpected urgency hint: %v", first.hints["urgency"])
+	}
+	if first.expire != 3000 {
+		t.Fatalf("expected expire timeout 3000, got %d", first.expire)
+	}
+
+	second := fake.calls[1]
+	if second.replacesID != id || second.expire != 0 {
+		t.Fatalf("unexpected replacement notification: %+v", second)
+	}
+}
+
+func TestExpireMillis(t *testing.T) {
+	cases := []struct {
+		in   time.Duration
+		want int32
+	}{
+		{ExpireNever, 0},
+		{0, -1},
+		{1500 * time.Millisecond, 1500},
+		{30 * 24 * time.Hour, math.MaxInt32},
+	}
+	for _, c := range cases {
+		if got := expireMillis(c.in); got != c.want {
+			t.Errorf("expireMillis(%s) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
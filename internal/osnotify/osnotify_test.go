@@ -2,12 +2,18 @@ package osnotify
 
 import (
 	"context"
+	"os"
+	"runtime"
 	"testing"
 )
 
 func TestSendDispatchesNotification(t *testing.T) {
 	t.Parallel()
 
+	if runtime.GOOS == "linux" && os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		t.Skip("未检测到 D-Bus 会话总线，跳过系统通知测试")
+	}
+
 	ctx := context.Background()
 
 	if err := Send(ctx, "Notify MCP", "Triggered from go test"); err != nil {
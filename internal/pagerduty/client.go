@@ -0,0 +1,150 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+const (
+	// maxSummary 是 Events API v2 中 summary 字段的长度上限。
+	maxSummary = 1024
+	// maxDedupKey 是 dedup_key 字段的长度上限。
+	maxDedupKey = 255
+)
+
+var defaultSeverities = map[notify.Level]string{
+	notify.LevelInfo:     config.PagerDutySeverityInfo,
+	notify.LevelWarning:  config.PagerDutySeverityWarning,
+	notify.LevelError:    config.PagerDutySeverityError,
+	notify.LevelCritical: config.PagerDutySeverityCritical,
+}
+
+type eventPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp"`
+	CustomDetails map[string]string `json:"custom_details"`
+}
+
+type event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Payload     *eventPayload `json:"payload,omitempty"`
+}
+
+type response struct {
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors"`
+}
+
+// SendMessage triggers a PagerDuty incident for the task when the level reaches
+// the configured minimum, or resolves it when the level is success. Events for
+// the same task share a dedup key. Lower levels are not sent and return an
+// error wrapping notify.ErrSkipped.
+func SendMessage(ctx context.Context, cfg config.PagerDutyConfig, msg notify.Message) error {
+	ev, ok := buildEvent(cfg, msg)
+	if !ok {
+		return fmt.Errorf("level %s is below pagerduty min level %s: %w", msg.Level, cfg.TriggerLevel(), notify.ErrSkipped)
+	}
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("encode pagerduty event: %w", err)
+	}
+
+	endpoint := strings.TrimRight(cfg.APIBaseURL, "/") + "/v2/enqueue"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build pagerduty request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call pagerduty: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var result response
+		if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && result.Message != "" {
+			return fmt.Errorf("pagerduty responded with %s: %s %s", resp.Status, result.Message, strings.Join(result.Errors, "; "))
+		}
+		return fmt.Errorf("pagerduty responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// buildEvent 构造事件，级别低于最低告警级别时返回 false，表示无需发送。
+func buildEvent(cfg config.PagerDutyConfig, msg notify.Message) (event, bool) {
+	ev := event{
+		RoutingKey: cfg.RoutingKey,
+		DedupKey:   dedupKey(msg.TaskName),
+	}
+	if msg.Level == notify.LevelSuccess {
+		ev.EventAction = "resolve"
+		return ev, true
+	}
+	if !msg.Level.AtLeast(notify.Level(cfg.TriggerLevel())) {
+		return event{}, false
+	}
+
+	severity, ok := cfg.Severities[string(msg.Level)]
+	if !ok {
+		severity, ok = defaultSeverities[msg.Level]
+	}
+	if !ok {
+		severity = config.PagerDutySeverityInfo
+	}
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "notify-mcp"
+	}
+
+	ev.EventAction = "trigger"
+	ev.Payload = &eventPayload{
+		Summary:   truncate(fmt.Sprintf("%s：%s", msg.TaskName, msg.Body), maxSummary),
+		Source:    host,
+		Severity:  severity,
+		Timestamp: msg.Time.Format(time.RFC3339),
+		CustomDetails: map[string]string{
+			"task":    msg.TaskName,
+			"message": msg.Body,
+			"level":   string(msg.Level),
+			"time":    msg.FormattedTime(),
+		},
+	}
+	return ev, true
+}
+
+// dedupKey 根据任务名称生成去重 key，超长时退化为哈希以满足长度限制。
+func dedupKey(taskName string) string {
+	key := "notify-mcp/" + taskName
+	if len(key) <= maxDedupKey {
+		return key
+	}
+	sum := sha256.Sum256([]byte(taskName))
+	return "notify-mcp/" + hex.EncodeToString(sum[:])
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,121 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageTriggerAndResolve(t *testing.T) {
+	t.Parallel()
+
+	var events []event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/enqueue" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var ev event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		if ev.RoutingKey != "R0UT1NG" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"invalid event","message":"Event object is invalid","errors":["Invalid routing key"]}`))
+			return
+		}
+		events = append(events, ev)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"success","message":"Event processed"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.PagerDutyConfig{
+		APIBaseURL: srv.URL,
+		RoutingKey: "R0UT1NG",
+		Severities: map[string]string{"error": config.PagerDutySeverityCritical},
+	}
+	failed := notify.Message{Time: time.Now(), TaskName: "夜间迁移", Body: "卡住了", Level: notify.LevelError}
+	if err := SendMessage(context.Background(), cfg, failed); err != nil {
+		t.Fatalf("trigger returned error: %v", err)
+	}
+	done := notify.Message{Time: time.Now(), TaskName: "夜间迁移", Body: "完成", Level: notify.LevelSuccess}
+	if err := SendMessage(context.Background(), cfg, done); err != nil {
+		t.Fatalf("resolve returned error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	trigger, resolve := events[0], events[1]
+	if trigger.EventAction != "trigger" || trigger.Payload == nil || trigger.Payload.Severity != "critical" {
+		t.Fatalf("unexpected trigger event: %+v", trigger)
+	}
+	if resolve.EventAction != "resolve" || resolve.Payload != nil {
+		t.Fatalf("unexpected resolve event: %+v", resolve)
+	}
+	if trigger.DedupKey == "" || trigger.DedupKey != resolve.DedupKey {
+		t.Fatalf("dedup keys differ: %q vs %q", trigger.DedupKey, resolve.DedupKey)
+	}
+
+	cfg.RoutingKey = "wrong"
+	if err := SendMessage(context.Background(), cfg, failed); err == nil {
+		t.Fatal("expected error for invalid routing key")
+	}
+}
+
+func TestBuildEventMinLevel(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		level    notify.Level
+		minLevel string
+		action   string
+	}{
+		{notify.LevelInfo, "", ""},
+		{notify.LevelWarning, "", ""},
+		{notify.LevelError, "", "trigger"},
+		{notify.LevelCritical, "", "trigger"},
+		{notify.LevelSuccess, "", "resolve"},
+		{notify.LevelInfo, "warning", ""},
+		{notify.LevelWarning, "warning", "trigger"},
+		{notify.LevelInfo, "info", "trigger"},
+		{notify.LevelError, "critical", ""},
+		{notify.LevelSuccess, "critical", "resolve"},
+	}
+	for _, tc := range cases {
+		cfg := config.PagerDutyConfig{RoutingKey: "R0UT1NG", MinLevel: tc.minLevel}
+		msg := notify.Message{Time: time.Now(), TaskName: "备份", Body: "内容", Level: tc.level}
+		ev, ok := buildEvent(cfg, msg)
+		if tc.action == "" {
+			if ok {
+				t.Errorf("level %s with min %q: expected no event, got %+v", tc.level, tc.minLevel, ev)
+			}
+			continue
+		}
+		if !ok || ev.EventAction != tc.action {
+			t.Errorf("level %s with min %q: expected %s, got %+v (ok=%v)", tc.level, tc.minLevel, tc.action, ev, ok)
+		}
+	}
+}
+
+func TestSendMessageSkipsBelowMinLevel(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request for level below min: %s", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	cfg := config.PagerDutyConfig{APIBaseURL: srv.URL, RoutingKey: "R0UT1NG"}
+	msg := notify.Message{Time: time.Now(), TaskName: "备份", Body: "进度 50%", Level: notify.LevelInfo}
+	if err := SendMessage(context.Background(), cfg, msg); !errors.Is(err, notify.ErrSkipped) {
+		t.Fatalf("expected skipped error, got %v", err)
+	}
+}
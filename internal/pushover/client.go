@@ -0,0 +1,139 @@
+package pushover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type response struct {
+	Status  int      `json:"status"`
+	Request string   `json:"request"`
+	Receipt string   `json:"receipt"`
+	Errors  []string `json:"errors"`
+}
+
+type receiptResponse struct {
+	response
+	Acknowledged    int    `json:"acknowledged"`
+	AcknowledgedAt  int64  `json:"acknowledged_at"`
+	AcknowledgedBy  string `json:"acknowledged_by"`
+	Expired         int    `json:"expired"`
+	ExpiresAt       int64  `json:"expires_at"`
+	LastDeliveredAt int64  `json:"last_delivered_at"`
+}
+
+// Receipt describes the acknowledgement state of an emergency-priority message.
+type Receipt struct {
+	ID             string
+	Acknowledged   bool
+	AcknowledgedAt time.Time
+	AcknowledgedBy string
+	Expired        bool
+	ExpiresAt      time.Time
+}
+
+// SendMessage posts a message to Pushover. For emergency priority it returns
+// the receipt ID that can later be polled with GetReceipt.
+func SendMessage(ctx context.Context, cfg config.PushoverConfig, msg notify.Message) (string, error) {
+	form := url.Values{}
+	form.Set("token", cfg.Token)
+	form.Set("user", cfg.UserKey)
+	form.Set("title", msg.TaskName)
+	form.Set("message", fmt.Sprintf("%s\n时间：%s", msg.Body, msg.FormattedTime()))
+	form.Set("timestamp", strconv.FormatInt(msg.Time.Unix(), 10))
+	form.Set("priority", strconv.Itoa(cfg.Priority))
+	if cfg.Device != "" {
+		form.Set("device", cfg.Device)
+	}
+	if cfg.Sound != "" {
+		form.Set("sound", cfg.Sound)
+	}
+	if cfg.Priority == config.PushoverPriorityEmergency {
+		form.Set("retry", strconv.Itoa(cfg.Retry))
+		form.Set("expire", strconv.Itoa(cfg.Expire))
+	}
+
+	endpoint := strings.TrimRight(cfg.APIBaseURL, "/") + "/1/messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("build pushover request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var result response
+	if err := do(req, &result); err != nil {
+		return "", err
+	}
+	return result.Receipt, nil
+}
+
+// GetReceipt polls the acknowledgement state of an emergency-priority message.
+func GetReceipt(ctx context.Context, cfg config.PushoverConfig, receipt string) (Receipt, error) {
+	endpoint := fmt.Sprintf("%s/1/receipts/%s.json?token=%s",
+		strings.TrimRight(cfg.APIBaseURL, "/"),
+		url.PathEscape(receipt),
+		url.QueryEscape(cfg.Token),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return Receipt{}, fmt.Errorf("build pushover receipt request: %w", err)
+	}
+
+	var result receiptResponse
+	if err := do(req, &result); err != nil {
+		return Receipt{}, err
+	}
+
+	r := Receipt{
+		ID:             receipt,
+		Acknowledged:   result.Acknowledged == 1,
+		AcknowledgedBy: result.AcknowledgedBy,
+		Expired:        result.Expired == 1,
+	}
+	if result.AcknowledgedAt > 0 {
+		r.AcknowledgedAt = time.Unix(result.AcknowledgedAt, 0)
+	}
+	if result.ExpiresAt > 0 {
+		r.ExpiresAt = time.Unix(result.ExpiresAt, 0)
+	}
+	return r, nil
+}
+
+// do 执行请求并解析响应；Pushover 以 status != 1 表示失败，errors 中给出原因。
+func do(req *http.Request, out any) error {
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call pushover: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("pushover responded with %s", resp.Status)
+		}
+		return fmt.Errorf("decode pushover response: %w", err)
+	}
+
+	var status response
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return fmt.Errorf("decode pushover response: %w", err)
+	}
+	if status.Status != 1 || resp.StatusCode >= 300 {
+		return fmt.Errorf("pushover responded with %s: %s", resp.Status, strings.Join(status.Errors, "; "))
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("decode pushover response: %w", err)
+	}
+	return nil
+}
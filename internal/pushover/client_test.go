@@ -0,0 +1,121 @@
+package pushover
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageEmergency(t *testing.T) {
+	t.Parallel()
+
+	var form map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1/messages.json" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse form: %v", err)
+		}
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		if form["token"] != "app" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"token":"invalid","errors":["application token is invalid"],"status":0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":1,"request":"req-1","receipt":"rcpt-1"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.PushoverConfig{
+		APIBaseURL: srv.URL,
+		Token:      "app",
+		UserKey:    "user",
+		Device:     "phone",
+		Priority:   config.PushoverPriorityEmergency,
+		Retry:      60,
+		Expire:     3600,
+	}
+	msg := notify.Message{Time: time.Unix(1700000000, 0), TaskName: "部署", Body: "需要确认"}
+	receipt, err := SendMessage(context.Background(), cfg, msg)
+	if err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if receipt != "rcpt-1" {
+		t.Fatalf("unexpected receipt %q", receipt)
+	}
+	want := map[string]string{
+		"user": "user", "device": "phone", "title": "部署", "timestamp": "1700000000",
+		"priority": "2", "retry": "60", "expire": "3600",
+	}
+	for key, value := range want {
+		if form[key] != value {
+			t.Errorf("form[%s] = %q, want %q", key, form[key], value)
+		}
+	}
+
+	// 非紧急优先级不携带 retry / expire，也没有回执。
+	cfg.Priority = 1
+	if _, err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if _, ok := form["retry"]; ok || form["priority"] != "1" {
+		t.Fatalf("unexpected non-emergency form: %v", form)
+	}
+
+	cfg.Token = "wrong"
+	_, err = SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "application token is invalid") {
+		t.Fatalf("expected token error, got %v", err)
+	}
+}
+
+func TestGetReceipt(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token") != "app" {
+			t.Errorf("unexpected token %q", r.URL.Query().Get("token"))
+		}
+		switch r.URL.Path {
+		case "/1/receipts/acked.json":
+			_, _ = w.Write([]byte(`{"status":1,"acknowledged":1,"acknowledged_at":1700000100,"acknowledged_by":"uQiRzpo4DXghDmr9QzzfQu27cmVRsG","expired":0,"expires_at":1700003600}`))
+		case "/1/receipts/expired.json":
+			_, _ = w.Write([]byte(`{"status":1,"acknowledged":0,"expired":1,"expires_at":1700003600}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"receipt":"not found","status":0,"errors":["receipt not found; may be invalid or expired"]}`))
+		}
+	}))
+	defer srv.Close()
+
+	cfg := config.PushoverConfig{APIBaseURL: srv.URL, Token: "app"}
+	acked, err := GetReceipt(context.Background(), cfg, "acked")
+	if err != nil {
+		t.Fatalf("GetReceipt returned error: %v", err)
+	}
+	if !acked.Acknowledged || acked.Expired || acked.AcknowledgedAt.Unix() != 1700000100 || acked.ExpiresAt.Unix() != 1700003600 {
+		t.Fatalf("unexpected acknowledged receipt: %+v", acked)
+	}
+
+	expired, err := GetReceipt(context.Background(), cfg, "expired")
+	if err != nil {
+		t.Fatalf("GetReceipt returned error: %v", err)
+	}
+	if expired.Acknowledged || !expired.Expired || !expired.AcknowledgedAt.IsZero() {
+		t.Fatalf("unexpected expired receipt: %+v", expired)
+	}
+
+	if _, err := GetReceipt(context.Background(), cfg, "missing"); err == nil || !strings.Contains(err.Error(), "receipt not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package pushplus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+// successCode 是 PushPlus 表示请求成功的业务码，与多数接口的 0 不同。
+const successCode = 200
+
+type payload struct {
+	Token    string `json:"token"`
+	Title    string `json:"title"`
+	Content  string `json:"content"`
+	Topic    string `json:"topic,omitempty"`
+	Template string `json:"template"`
+}
+
+type response struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// SendMessage pushes the notification to WeChat through PushPlus.
+func SendMessage(ctx context.Context, cfg config.PushPlusConfig, msg notify.Message) error {
+	endpoint := strings.TrimRight(cfg.APIBaseURL, "/") + "/send"
+
+	body, err := json.Marshal(payload{
+		Token:    cfg.Token,
+		Title:    msg.TaskName,
+		Content:  buildContent(cfg.Template, msg),
+		Topic:    cfg.Topic,
+		Template: cfg.Template,
+	})
+	if err != nil {
+		return fmt.Errorf("encode pushplus payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build pushplus request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call pushplus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("pushplus responded with %s", resp.Status)
+	}
+
+	// token 错误、群组不存在等错误以 HTTP 200 + 非 200 code 返回。
+	var result response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode pushplus response: %w", err)
+	}
+	if result.Code != successCode {
+		return fmt.Errorf("pushplus responded with code %d: %s", result.Code, result.Msg)
+	}
+	return nil
+}
+
+func buildContent(template string, msg notify.Message) string {
+	if template == config.PushPlusTemplateMarkdown {
+		var b strings.Builder
+		fmt.Fprintf(&b, "**时间**：%s\n\n", msg.FormattedTime())
+		fmt.Fprintf(&b, "**任务**：%s\n\n", msg.TaskName)
+		b.WriteString(msg.Body)
+		return b.String()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<p><b>时间</b>：%s</p>", html.EscapeString(msg.FormattedTime()))
+	fmt.Fprintf(&b, "<p><b>任务</b>：%s</p>", html.EscapeString(msg.TaskName))
+	fmt.Fprintf(&b, "<p>%s</p>", strings.ReplaceAll(html.EscapeString(msg.Body), "\n", "<br>"))
+	return b.String()
+}
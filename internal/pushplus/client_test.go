@@ -0,0 +1,61 @@
+package pushplus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/send" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		// PushPlus 业务错误同样以 HTTP 200 返回。
+		if got.Token != "tok" {
+			_, _ = w.Write([]byte(`{"code":903,"msg":"无效的用户token","data":null}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":200,"msg":"请求成功","data":"abc"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.PushPlusConfig{APIBaseURL: srv.URL, Token: "tok", Topic: "team", Template: config.PushPlusTemplateHTML}
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "a<b>\n完成", Level: notify.LevelSuccess}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if got.Title != "构建" || got.Topic != "team" || got.Template != "html" {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+	if !strings.Contains(got.Content, "a&lt;b&gt;<br>完成") {
+		t.Fatalf("html content not escaped: %q", got.Content)
+	}
+
+	cfg.Template = config.PushPlusTemplateMarkdown
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if got.Template != "markdown" || !strings.Contains(got.Content, "**任务**：构建") {
+		t.Fatalf("unexpected markdown payload: %+v", got)
+	}
+
+	cfg.Token = "wrong"
+	err := SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "code 903") {
+		t.Fatalf("expected code error, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package rocketchat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+var levelColors = map[notify.Level]string{
+	notify.LevelInfo:     "#3498DB",
+	notify.LevelSuccess:  "#2ECC71",
+	notify.LevelWarning:  "#F1C40F",
+	notify.LevelError:    "#E74C3C",
+	notify.LevelCritical: "#992D22",
+}
+
+type field struct {
+	Short bool   `json:"short"`
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type attachment struct {
+	Title  string  `json:"title"`
+	Text   string  `json:"text"`
+	Color  string  `json:"color"`
+	Fields []field `json:"fields"`
+}
+
+type payload struct {
+	Text        string       `json:"text"`
+	Channel     string       `json:"channel,omitempty"`
+	Alias       string       `json:"alias,omitempty"`
+	Avatar      string       `json:"avatar,omitempty"`
+	Emoji       string       `json:"emoji,omitempty"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type response struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+// SendMessage posts a markdown attachment to the configured Rocket.Chat incoming webhook.
+func SendMessage(ctx context.Context, cfg config.RocketChatConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode rocketchat payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build rocketchat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call rocketchat: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("rocketchat responded with %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	// 集成脚本出错等情况下 Rocket.Chat 会以 success=false 返回。
+	var result response
+	if err := json.Unmarshal(data, &result); err == nil && !result.Success {
+		return fmt.Errorf("rocketchat responded with error: %s", result.Error)
+	}
+	return nil
+}
+
+func buildPayload(cfg config.RocketChatConfig, msg notify.Message) payload {
+	color, ok := levelColors[msg.Level]
+	if !ok {
+		color = levelColors[notify.LevelInfo]
+	}
+	return payload{
+		Text:    fmt.Sprintf("**%s**", msg.TaskName),
+		Channel: cfg.Channel,
+		Alias:   cfg.Username,
+		Avatar:  cfg.IconURL,
+		Emoji:   cfg.IconEmoji,
+		Attachments: []attachment{
+			{
+				Title: msg.TaskName,
+				Text:  msg.Body,
+				Color: color,
+				Fields: []field{
+					{Short: true, Title: "时间", Value: msg.FormattedTime()},
+					{Short: true, Title: "任务", Value: msg.TaskName},
+				},
+			},
+		},
+	}
+}
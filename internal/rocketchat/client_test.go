@@ -0,0 +1,95 @@
+package rocketchat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.RocketChatConfig{
+		WebhookURL: srv.URL,
+		Channel:    "#ops",
+		Username:   "notify-bot",
+		IconURL:    "https://example.com/avatar.png",
+		IconEmoji:  ":robot:",
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "部署", Body: "完成", Level: notify.LevelSuccess}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if got.Channel != "#ops" || got.Alias != "notify-bot" || got.Avatar != cfg.IconURL || got.Emoji != ":robot:" {
+		t.Fatalf("overrides not sent: %+v", got)
+	}
+	if got.Text != "**部署**" || len(got.Attachments) != 1 {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+	a := got.Attachments[0]
+	if a.Title != "部署" || a.Text != "完成" || a.Color != "#2ECC71" {
+		t.Fatalf("unexpected attachment: %+v", a)
+	}
+	if len(a.Fields) != 2 || a.Fields[0].Value != msg.FormattedTime() || a.Fields[1].Value != "部署" {
+		t.Fatalf("unexpected fields: %+v", a.Fields)
+	}
+}
+
+func TestBuildPayloadLevelColors(t *testing.T) {
+	t.Parallel()
+
+	for level, color := range levelColors {
+		p := buildPayload(config.RocketChatConfig{}, notify.Message{TaskName: "任务", Level: level})
+		if p.Attachments[0].Color != color {
+			t.Errorf("level %s: color %s, want %s", level, p.Attachments[0].Color, color)
+		}
+	}
+}
+
+func TestSendMessageErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/script-error":
+			// 集成脚本出错时 Rocket.Chat 仍返回 200。
+			_, _ = w.Write([]byte(`{"success":false,"error":"Error running script"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"error":"Invalid integration id or token provided."}`))
+		}
+	}))
+	defer srv.Close()
+
+	msg := notify.Message{Time: time.Now(), TaskName: "部署", Body: "失败", Level: notify.LevelError}
+	cases := map[string]string{
+		"/script-error": "Error running script",
+		"/hooks/bad":    "404",
+	}
+	for path, want := range cases {
+		err := SendMessage(context.Background(), config.RocketChatConfig{WebhookURL: srv.URL + path}, msg)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got %v", path, want, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package serverchan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+const turboAPIBaseURL = "https://sctapi.ftqq.com"
+
+// v3KeyPattern 匹配 Server酱³ 的 SendKey（sctp{uid}t...），用于推导专属接口域名。
+var v3KeyPattern = regexp.MustCompile(`^sctp(\d+)t`)
+
+type response struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// SendMessage pushes the notification through ServerChan Turbo or ServerChan³,
+// selected by the SendKey format.
+func SendMessage(ctx context.Context, cfg config.ServerChanConfig, msg notify.Message) error {
+	form := url.Values{}
+	form.Set("title", msg.TaskName)
+	form.Set("desp", buildDesp(msg))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint(cfg), strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("build serverchan request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call serverchan: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// SendKey 无效、超出每日额度等错误可能以 HTTP 200 + 非 0 code 返回，需优先解析响应体。
+	var result response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("serverchan responded with %s", resp.Status)
+		}
+		return fmt.Errorf("decode serverchan response: %w", err)
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("serverchan responded with code %d: %s", result.Code, result.Message)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("serverchan responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// endpoint 根据 SendKey 版本拼接推送地址，APIBaseURL 仅替换域名部分。
+func endpoint(cfg config.ServerChanConfig) string {
+	key := url.PathEscape(cfg.SendKey)
+	if m := v3KeyPattern.FindStringSubmatch(cfg.SendKey); m != nil {
+		base := fmt.Sprintf("https://%s.push.ft07.com", m[1])
+		if cfg.APIBaseURL != "" {
+			base = strings.TrimRight(cfg.APIBaseURL, "/")
+		}
+		return fmt.Sprintf("%s/send/%s.send", base, key)
+	}
+
+	base := turboAPIBaseURL
+	if cfg.APIBaseURL != "" {
+		base = strings.TrimRight(cfg.APIBaseURL, "/")
+	}
+	return fmt.Sprintf("%s/%s.send", base, key)
+}
+
+func buildDesp(msg notify.Message) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**时间**：%s\n\n", msg.FormattedTime())
+	fmt.Fprintf(&b, "**任务**：%s\n\n", msg.TaskName)
+	b.WriteString(msg.Body)
+	return b.String()
+}
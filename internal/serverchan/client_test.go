@@ -0,0 +1,57 @@
+package serverchan
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var gotPath, gotTitle string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotTitle = r.FormValue("title")
+		if r.URL.Path == "/send/sctp42tbad.send" {
+			_, _ = w.Write([]byte(`{"code":40001,"message":"bad pushkey"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":0,"message":"","data":{"errno":0}}`))
+	}))
+	defer srv.Close()
+
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "完成"}
+
+	cfg := config.ServerChanConfig{SendKey: "SCT123abc", APIBaseURL: srv.URL}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if gotPath != "/SCT123abc.send" || gotTitle != "构建" {
+		t.Fatalf("unexpected turbo request: path=%q title=%q", gotPath, gotTitle)
+	}
+
+	cfg.SendKey = "sctp42tbad"
+	if err := SendMessage(context.Background(), cfg, msg); err == nil {
+		t.Fatal("expected error for non-zero code")
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"SCT123abc":    "https://sctapi.ftqq.com/SCT123abc.send",
+		"sctp1234tabc": "https://1234.push.ft07.com/send/sctp1234tabc.send",
+	}
+	for key, want := range cases {
+		if got := endpoint(config.ServerChanConfig{SendKey: key}); got != want {
+			t.Errorf("endpoint(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
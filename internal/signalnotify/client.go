@@ -0,0 +1,90 @@
+package signalnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type payload struct {
+	Message    string   `json:"message"`
+	Number     string   `json:"number"`
+	Recipients []string `json:"recipients"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Failure records why sending to a single recipient or group failed.
+type Failure struct {
+	Recipient string
+	Err       error
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s（%v）", f.Recipient, f.Err)
+}
+
+// SendMessage sends the notification through signal-cli-rest-api, one request
+// per recipient or group so failed targets can be told apart. The failures are
+// always returned; the error is non-nil only when every target failed.
+func SendMessage(ctx context.Context, cfg config.SignalConfig, msg notify.Message) ([]Failure, error) {
+	endpoint := strings.TrimRight(cfg.ServerURL, "/") + "/v2/send"
+	client := &http.Client{Timeout: 15 * time.Second}
+	text := msg.Text()
+
+	targets := append(append([]string(nil), cfg.Recipients...), cfg.GroupIDs...)
+	var failures []Failure
+	for _, target := range targets {
+		if err := send(ctx, client, endpoint, payload{
+			Message:    text,
+			Number:     cfg.Number,
+			Recipients: []string{target},
+		}); err != nil {
+			failures = append(failures, Failure{Recipient: target, Err: err})
+		}
+	}
+
+	if len(failures) == len(targets) {
+		return failures, fmt.Errorf("all %d signal recipients failed", len(targets))
+	}
+	return failures, nil
+}
+
+func send(ctx context.Context, client *http.Client, endpoint string, p payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encode signal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build signal request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call signal: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var result errorResponse
+		if err := json.Unmarshal(data, &result); err == nil && result.Error != "" {
+			return fmt.Errorf("signal responded with %s: %s", resp.Status, result.Error)
+		}
+		return fmt.Errorf("signal responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package signalnotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageReportsPerRecipientFailures(t *testing.T) {
+	t.Parallel()
+
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/send" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var p payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		if p.Number != "+15551234567" || len(p.Recipients) != 1 {
+			t.Errorf("unexpected payload: %+v", p)
+		}
+		if p.Recipients[0] == "+15550000002" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"Failed to send message: Unregistered user"}`))
+			return
+		}
+		sent = append(sent, p.Recipients[0])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"timestamp":"1700000000000"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.SignalConfig{
+		ServerURL:  srv.URL,
+		Number:     "+15551234567",
+		Recipients: []string{"+15550000001", "+15550000002"},
+		GroupIDs:   []string{"group.abc="},
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "发布", Body: "完成", Level: notify.LevelSuccess}
+
+	failures, err := SendMessage(context.Background(), cfg, msg)
+	if err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if len(failures) != 1 || failures[0].Recipient != "+15550000002" || !strings.Contains(failures[0].Err.Error(), "Unregistered user") {
+		t.Fatalf("unexpected failures: %v", failures)
+	}
+	if strings.Join(sent, ",") != "+15550000001,group.abc=" {
+		t.Fatalf("unexpected recipients: %v", sent)
+	}
+
+	cfg.Recipients = []string{"+15550000002"}
+	cfg.GroupIDs = nil
+	failures, err = SendMessage(context.Background(), cfg, msg)
+	if err == nil {
+		t.Fatal("expected error when every recipient fails")
+	}
+	if len(failures) != 1 || failures[0].Recipient != "+15550000002" {
+		t.Fatalf("expected failure detail when every recipient fails, got %v", failures)
+	}
+}
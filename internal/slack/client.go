@@ -0,0 +1,92 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type textObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type block struct {
+	Type   string       `json:"type"`
+	Text   *textObject  `json:"text,omitempty"`
+	Fields []textObject `json:"fields,omitempty"`
+}
+
+type payload struct {
+	Text      string  `json:"text"`
+	Blocks    []block `json:"blocks"`
+	Channel   string  `json:"channel,omitempty"`
+	Username  string  `json:"username,omitempty"`
+	IconEmoji string  `json:"icon_emoji,omitempty"`
+}
+
+// SendMessage posts a Block Kit message to the configured Slack incoming webhook.
+func SendMessage(ctx context.Context, cfg config.SlackConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		// Slack 会在响应体中给出 invalid_payload、channel_not_found 等错误码。
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func buildPayload(cfg config.SlackConfig, msg notify.Message) payload {
+	return payload{
+		// text 作为推送预览及不支持 blocks 的客户端的回退内容。
+		Text: msg.Text(),
+		Blocks: []block{
+			{
+				Type: "section",
+				Fields: []textObject{
+					{Type: "mrkdwn", Text: "*时间*\n" + escape(msg.FormattedTime())},
+					{Type: "mrkdwn", Text: "*任务*\n" + escape(msg.TaskName)},
+				},
+			},
+			{
+				Type: "section",
+				Text: &textObject{Type: "mrkdwn", Text: escape(msg.Body)},
+			},
+		},
+		Channel:   cfg.Channel,
+		Username:  cfg.Username,
+		IconEmoji: cfg.IconEmoji,
+	}
+}
+
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escape 转义 Slack mrkdwn 中具有控制含义的字符。
+func escape(s string) string {
+	return escaper.Replace(s)
+}
@@ -0,0 +1,61 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	cfg := config.SlackConfig{WebhookURL: srv.URL, Channel: "#alerts", Username: "bot", IconEmoji: ":robot_face:"}
+	msg := notify.Message{Time: time.Now(), TaskName: "a<b>", Body: "完成 & 退出", Level: notify.LevelSuccess}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if got.Channel != "#alerts" || got.Username != "bot" || got.IconEmoji != ":robot_face:" {
+		t.Fatalf("unexpected overrides: %+v", got)
+	}
+	if len(got.Blocks) != 2 || got.Blocks[0].Fields[1].Text != "*任务*\na&lt;b&gt;" {
+		t.Fatalf("unexpected blocks: %+v", got.Blocks)
+	}
+	if got.Blocks[1].Text == nil || got.Blocks[1].Text.Text != "完成 &amp; 退出" {
+		t.Fatalf("unexpected body block: %+v", got.Blocks[1])
+	}
+}
+
+func TestSendMessageErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("channel_not_found"))
+	}))
+	defer srv.Close()
+
+	err := SendMessage(context.Background(), config.SlackConfig{WebhookURL: srv.URL}, notify.Message{Time: time.Now()})
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Fatalf("expected channel_not_found error, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package syslognotify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+// severity 对应 syslog 协议（RFC 5424）中的严重级别。
+type severity int
+
+const (
+	severityCrit    severity = 2
+	severityErr     severity = 3
+	severityWarning severity = 4
+	severityNotice  severity = 5
+	severityInfo    severity = 6
+)
+
+var levelSeverities = map[notify.Level]severity{
+	notify.LevelInfo:     severityInfo,
+	notify.LevelSuccess:  severityNotice,
+	notify.LevelWarning:  severityWarning,
+	notify.LevelError:    severityErr,
+	notify.LevelCritical: severityCrit,
+}
+
+// record 是一条待写入系统日志的通知。
+type record struct {
+	ident    string
+	severity severity
+	task     string
+	level    string
+	message  string
+}
+
+// SendMessage writes the notification to the local syslog daemon or journald.
+// Journald entries also carry TASK= and LEVEL= fields for journalctl filtering.
+func SendMessage(ctx context.Context, cfg config.SyslogConfig, msg notify.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sev, ok := levelSeverities[msg.Level]
+	if !ok {
+		sev = severityInfo
+	}
+	rec := record{
+		ident:    cfg.Ident,
+		severity: sev,
+		task:     msg.TaskName,
+		level:    string(msg.Level),
+		message:  fmt.Sprintf("[%s] %s：%s", msg.Level, msg.TaskName, strings.ReplaceAll(msg.Body, "\n", " ")),
+	}
+
+	backend := cfg.Backend
+	if backend == config.SyslogBackendAuto {
+		backend = config.SyslogBackendSyslog
+		if journalAvailable() {
+			backend = config.SyslogBackendJournald
+		}
+	}
+	if backend == config.SyslogBackendJournald {
+		return writeJournal(ctx, rec)
+	}
+	return writeSyslog(rec)
+}
@@ -0,0 +1,89 @@
+//go:build !windows && !plan9
+
+package syslognotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/syslog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// journalSocket 是 systemd-journald 原生协议的数据报套接字。
+var journalSocket = "/run/systemd/journal/socket"
+
+func journalAvailable() bool {
+	_, err := os.Stat(journalSocket)
+	return err == nil
+}
+
+// writeSyslog 通过本地 syslog 套接字（/dev/log 等）写入一条日志。
+func writeSyslog(rec record) error {
+	w, err := syslog.Dial("", "", syslog.LOG_USER|syslog.Priority(rec.severity), rec.ident)
+	if err != nil {
+		return fmt.Errorf("connect syslog: %w", err)
+	}
+	defer w.Close()
+
+	switch rec.severity {
+	case severityCrit:
+		err = w.Crit(rec.message)
+	case severityErr:
+		err = w.Err(rec.message)
+	case severityWarning:
+		err = w.Warning(rec.message)
+	case severityNotice:
+		err = w.Notice(rec.message)
+	default:
+		err = w.Info(rec.message)
+	}
+	if err != nil {
+		return fmt.Errorf("write syslog: %w", err)
+	}
+	return nil
+}
+
+// writeJournal 按 journald 原生协议发送一条带结构化字段的日志。
+func writeJournal(ctx context.Context, rec record) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unixgram", journalSocket)
+	if err != nil {
+		return fmt.Errorf("connect journald: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+
+	var buf bytes.Buffer
+	writeField(&buf, "MESSAGE", rec.message)
+	writeField(&buf, "PRIORITY", strconv.Itoa(int(rec.severity)))
+	writeField(&buf, "SYSLOG_IDENTIFIER", rec.ident)
+	writeField(&buf, "TASK", rec.task)
+	writeField(&buf, "LEVEL", rec.level)
+
+	if _, err := conn.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write journald: %w", err)
+	}
+	return nil
+}
+
+// writeField 写入一个字段，值中含换行时需使用带 64 位小端长度前缀的二进制格式。
+func writeField(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,47 @@
+//go:build !windows && !plan9
+
+package syslognotify
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageToJournal(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: sock, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("无法创建 unixgram 套接字: %v", err)
+	}
+	defer conn.Close()
+
+	orig := journalSocket
+	journalSocket = sock
+	t.Cleanup(func() { journalSocket = orig })
+
+	cfg := config.SyslogConfig{Backend: config.SyslogBackendAuto, Ident: "notify-mcp"}
+	msg := notify.Message{Time: time.Now(), TaskName: "迁移", Body: "失败", Level: notify.LevelError}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("read journal datagram: %v", err)
+	}
+	got := string(buf[:n])
+	for _, want := range []string{"PRIORITY=3\n", "SYSLOG_IDENTIFIER=notify-mcp\n", "TASK=迁移\n", "LEVEL=error\n", "MESSAGE=[error] 迁移：失败\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("journal datagram missing %q: %q", want, got)
+		}
+	}
+}
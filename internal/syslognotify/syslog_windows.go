@@ -0,0 +1,22 @@
+//go:build windows
+
+package syslognotify
+
+import (
+	"context"
+	"errors"
+)
+
+var errUnsupported = errors.New("syslog and journald are not available on windows")
+
+func journalAvailable() bool {
+	return false
+}
+
+func writeSyslog(record) error {
+	return errUnsupported
+}
+
+func writeJournal(context.Context, record) error {
+	return errUnsupported
+}
@@ -0,0 +1,125 @@
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+var levelColors = map[notify.Level]string{
+	notify.LevelInfo:     "Accent",
+	notify.LevelSuccess:  "Good",
+	notify.LevelWarning:  "Warning",
+	notify.LevelError:    "Attention",
+	notify.LevelCritical: "Attention",
+}
+
+type fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type element struct {
+	Type   string `json:"type"`
+	Text   string `json:"text,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Wrap   bool   `json:"wrap,omitempty"`
+	Facts  []fact `json:"facts,omitempty"`
+}
+
+type action struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type adaptiveCard struct {
+	Schema  string    `json:"$schema"`
+	Type    string    `json:"type"`
+	Version string    `json:"version"`
+	Body    []element `json:"body"`
+	Actions []action  `json:"actions,omitempty"`
+}
+
+type attachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type payload struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+// SendMessage posts an Adaptive Card to the configured Teams incoming webhook or Workflows URL.
+func SendMessage(ctx context.Context, cfg config.TeamsConfig, msg notify.Message) error {
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode teams payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build teams request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call teams: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 传统 Incoming Webhook 返回 200，Workflows 返回 202，均视为成功。
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("teams responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func buildPayload(cfg config.TeamsConfig, msg notify.Message) payload {
+	color, ok := levelColors[msg.Level]
+	if !ok {
+		color = levelColors[notify.LevelInfo]
+	}
+
+	card := adaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []element{
+			{Type: "TextBlock", Text: msg.TaskName, Weight: "Bolder", Size: "Medium", Color: color, Wrap: true},
+			{Type: "FactSet", Facts: []fact{
+				{Title: "时间", Value: msg.FormattedTime()},
+				{Title: "任务", Value: msg.TaskName},
+			}},
+			{Type: "TextBlock", Text: msg.Body, Wrap: true},
+		},
+	}
+	if cfg.LinkURL != "" {
+		card.Actions = []action{{Type: "Action.OpenUrl", Title: "Open", URL: cfg.LinkURL}}
+	}
+
+	return payload{
+		Type: "message",
+		Attachments: []attachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content:     card,
+			},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/expired" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Webhook expired"))
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		// Workflows 触发地址返回 202。
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	cfg := config.TeamsConfig{WebhookURL: srv.URL + "/workflow", LinkURL: "https://ci.example.com"}
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "失败", Level: notify.LevelError}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if got.Type != "message" || len(got.Attachments) != 1 || got.Attachments[0].ContentType != "application/vnd.microsoft.card.adaptive" {
+		t.Fatalf("unexpected envelope: %+v", got)
+	}
+	card := got.Attachments[0].Content
+	if card.Type != "AdaptiveCard" || card.Body[0].Color != "Attention" || card.Body[2].Text != "失败" {
+		t.Fatalf("unexpected card: %+v", card)
+	}
+	if len(card.Actions) != 1 || card.Actions[0].URL != cfg.LinkURL {
+		t.Fatalf("unexpected actions: %+v", card.Actions)
+	}
+
+	cfg.WebhookURL = srv.URL + "/expired"
+	err := SendMessage(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "Webhook expired") {
+		t.Fatalf("expected webhook error, got %v", err)
+	}
+}
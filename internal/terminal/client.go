@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+const (
+	esc = "\x1b"
+	bel = "\a"
+	// st 为 DCS 的结束符（String Terminator）。
+	st = esc + `\`
+)
+
+// SendMessage writes a desktop notification escape sequence to the controlling
+// terminal rather than stdout, which carries the MCP stdio transport.
+func SendMessage(ctx context.Context, cfg config.TerminalConfig, msg notify.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tty, err := openTTY(cfg.TTY)
+	if err != nil {
+		return fmt.Errorf("open terminal: %w", err)
+	}
+	defer tty.Close()
+
+	seq := wrap(resolvePassthrough(cfg.Passthrough), buildSequence(cfg.Protocol, msg))
+	if _, err := tty.WriteString(seq); err != nil {
+		return fmt.Errorf("write terminal: %w", err)
+	}
+	return nil
+}
+
+// buildSequence 按协议生成通知序列，统一使用 BEL 结尾，便于 screen 透传。
+func buildSequence(protocol string, msg notify.Message) string {
+	title := sanitize(msg.TaskName)
+	body := sanitize(msg.Body)
+
+	switch protocol {
+	case config.TerminalProtocolOSC777:
+		// OSC 777 以分号分隔标题与正文，标题中的分号需要替换掉。
+		return esc + "]777;notify;" + strings.ReplaceAll(title, ";", "；") + ";" + body + bel
+	case config.TerminalProtocolOSC99:
+		// kitty 使用 d=0 标记未完成的通知，随后以 p=body 追加正文并展示。
+		return esc + "]99;i=notify-mcp:d=0;" + title + bel +
+			esc + "]99;i=notify-mcp:d=1:p=body;" + body + bel
+	default:
+		return esc + "]9;" + title + "：" + body + bel
+	}
+}
+
+// resolvePassthrough 将 auto 解析为实际的终端复用器类型。
+func resolvePassthrough(mode string) string {
+	if mode != config.TerminalPassthroughAuto && mode != "" {
+		return mode
+	}
+	switch {
+	case os.Getenv("TMUX") != "":
+		return config.TerminalPassthroughTmux
+	case os.Getenv("STY") != "":
+		return config.TerminalPassthroughScreen
+	default:
+		return config.TerminalPassthroughNone
+	}
+}
+
+// wrap 使用 DCS 包裹序列，使其穿过 tmux / screen 直达外层终端。
+// tmux 3.3 起需要开启 allow-passthrough 选项。
+func wrap(mode, seq string) string {
+	switch mode {
+	case config.TerminalPassthroughTmux:
+		return esc + "Ptmux;" + strings.ReplaceAll(seq, esc, esc+esc) + st
+	case config.TerminalPassthroughScreen:
+		return esc + "P" + seq + st
+	default:
+		return seq
+	}
+}
+
+// sanitize 去除控制字符，避免通知内容提前结束序列或注入其他转义指令。
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return ' '
+		case r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0):
+			return -1
+		default:
+			return r
+		}
+	}, s)
+}
@@ -0,0 +1,52 @@
+package terminal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "完成\x1b]0;evil\a"}
+	cases := []struct {
+		cfg  config.TerminalConfig
+		want string
+	}{
+		{
+			cfg:  config.TerminalConfig{Protocol: config.TerminalProtocolOSC9, Passthrough: config.TerminalPassthroughNone},
+			want: "\x1b]9;构建：完成]0;evil\a",
+		},
+		{
+			cfg:  config.TerminalConfig{Protocol: config.TerminalProtocolOSC777, Passthrough: config.TerminalPassthroughScreen},
+			want: "\x1bP\x1b]777;notify;构建;完成]0;evil\a\x1b\\",
+		},
+		{
+			cfg:  config.TerminalConfig{Protocol: config.TerminalProtocolOSC99, Passthrough: config.TerminalPassthroughTmux},
+			want: "\x1bPtmux;\x1b\x1b]99;i=notify-mcp:d=0;构建\a\x1b\x1b]99;i=notify-mcp:d=1:p=body;完成]0;evil\a\x1b\\",
+		},
+	}
+
+	for _, tc := range cases {
+		tc.cfg.TTY = filepath.Join(t.TempDir(), "tty")
+		if err := os.WriteFile(tc.cfg.TTY, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := SendMessage(context.Background(), tc.cfg, msg); err != nil {
+			t.Fatalf("%s: SendMessage returned error: %v", tc.cfg.Protocol, err)
+		}
+		got, err := os.ReadFile(tc.cfg.TTY)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.cfg.Protocol, got, tc.want)
+		}
+	}
+}
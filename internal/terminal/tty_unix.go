@@ -0,0 +1,13 @@
+//go:build !windows
+
+package terminal
+
+import "os"
+
+// openTTY 打开控制终端 /dev/tty，即使 stdin/stdout 被重定向也能写到用户的终端。
+func openTTY(path string) (*os.File, error) {
+	if path == "" {
+		path = "/dev/tty"
+	}
+	return os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+}
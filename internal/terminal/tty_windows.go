@@ -0,0 +1,13 @@
+//go:build windows
+
+package terminal
+
+import "os"
+
+// openTTY 打开当前控制台的输出缓冲区 CONOUT$，不依赖标准输出。
+func openTTY(path string) (*os.File, error) {
+	if path == "" {
+		path = "CONOUT$"
+	}
+	return os.OpenFile(path, os.O_WRONLY, 0)
+}
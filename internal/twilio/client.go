@@ -0,0 +1,142 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+const (
+	// maxSMSRunes 限制短信长度。Twilio 允许 1600 字符，但长短信会拆分为多条计费，
+	// 中文短信每条仅 70 字，因此只保留足以定位问题的摘要。
+	maxSMSRunes = 140
+	// maxSayRunes 限制语音播报的长度，避免电话过长。
+	maxSayRunes = 300
+)
+
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Failure records why sending an SMS to, or calling, a single number failed.
+type Failure struct {
+	Recipient string
+	Err       error
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s（%v）", f.Recipient, f.Err)
+}
+
+// SendMessage sends the notification as an SMS to every configured number and,
+// for critical notifications with VoiceOnCritical enabled, also places a call.
+// Each number is handled independently: the failures are always returned, and
+// the error is non-nil only when no number received either an SMS or a call.
+func SendMessage(ctx context.Context, cfg config.TwilioConfig, msg notify.Message) ([]Failure, error) {
+	base := strings.TrimRight(cfg.APIBaseURL, "/") + "/2010-04-01/Accounts/" + url.PathEscape(cfg.AccountSID)
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	smsBody := truncate(fmt.Sprintf("[%s] %s", msg.TaskName, msg.Body), maxSMSRunes)
+	call := cfg.VoiceOnCritical && msg.Level == notify.LevelCritical
+	var twiml string
+	if call {
+		twiml = buildTwiML(cfg.VoiceLanguage, msg)
+	}
+
+	var failures []Failure
+	reached := 0
+	for _, to := range cfg.To {
+		// 短信或电话任一成功即视为该号码已送达。
+		delivered := false
+		form := url.Values{}
+		form.Set("From", cfg.From)
+		form.Set("To", to)
+		form.Set("Body", smsBody)
+		if err := post(ctx, client, cfg, base+"/Messages.json", form); err != nil {
+			failures = append(failures, Failure{Recipient: to, Err: fmt.Errorf("send sms: %w", err)})
+		} else {
+			delivered = true
+		}
+
+		if call {
+			form = url.Values{}
+			form.Set("From", cfg.From)
+			form.Set("To", to)
+			form.Set("Twiml", twiml)
+			if err := post(ctx, client, cfg, base+"/Calls.json", form); err != nil {
+				failures = append(failures, Failure{Recipient: to, Err: fmt.Errorf("place call: %w", err)})
+			} else {
+				delivered = true
+			}
+		}
+		if delivered {
+			reached++
+		}
+	}
+
+	if reached == 0 {
+		return failures, fmt.Errorf("all %d twilio numbers failed", len(cfg.To))
+	}
+	return failures, nil
+}
+
+func post(ctx context.Context, client *http.Client, cfg config.TwilioConfig, endpoint string, form url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("build twilio request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(cfg.AccountSID, cfg.AuthToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call twilio: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var result errorResponse
+		if err := json.Unmarshal(data, &result); err == nil && result.Message != "" {
+			return fmt.Errorf("twilio responded with %s: %d %s", resp.Status, result.Code, result.Message)
+		}
+		return fmt.Errorf("twilio responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// buildTwiML 生成使用 <Say> 播报通知内容的 TwiML 文档。
+func buildTwiML(language string, msg notify.Message) string {
+	var text strings.Builder
+	xml.EscapeText(&text, []byte(truncate(fmt.Sprintf("任务 %s 需要立即处理。%s", msg.TaskName, msg.Body), maxSayRunes)))
+
+	var b strings.Builder
+	b.WriteString("<Response><Say")
+	if language != "" {
+		b.WriteString(` language="`)
+		xml.EscapeText(&b, []byte(language))
+		b.WriteString(`"`)
+	}
+	b.WriteString(">")
+	b.WriteString(text.String())
+	b.WriteString("</Say></Response>")
+	return b.String()
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,100 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageCriticalPlacesCall(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		messages []string
+		calls    []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "AC123" || pass != "secret" {
+			t.Errorf("unexpected basic auth %q/%q", user, pass)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse form: %v", err)
+		}
+		if r.PostForm.Get("To") == "+15550000002" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":21211,"message":"Invalid 'To' Phone Number","status":400}`))
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/2010-04-01/Accounts/AC123/Messages.json":
+			messages = append(messages, r.PostForm.Get("Body"))
+		case "/2010-04-01/Accounts/AC123/Calls.json":
+			calls = append(calls, r.PostForm.Get("Twiml"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"sid":"SM123"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.TwilioConfig{
+		APIBaseURL:      srv.URL,
+		AccountSID:      "AC123",
+		AuthToken:       "secret",
+		From:            "+15551234567",
+		To:              []string{"+15550000001", "+15550000002"},
+		VoiceOnCritical: true,
+		VoiceLanguage:   "cmn-CN",
+	}
+	msg := notify.Message{
+		Time:     time.Now(),
+		TaskName: "数据库<主库>",
+		Body:     strings.Repeat("磁盘已满", 100),
+		Level:    notify.LevelCritical,
+	}
+
+	failures, err := SendMessage(context.Background(), cfg, msg)
+	if err != nil {
+		t.Fatalf("one failed number should not fail the method: %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected sms and call failures for second number, got %v", failures)
+	}
+	for _, f := range failures {
+		if f.Recipient != "+15550000002" || !strings.Contains(f.Err.Error(), "21211") {
+			t.Fatalf("unexpected failure: %v", f)
+		}
+	}
+
+	if len(messages) != 1 || len([]rune(messages[0])) != maxSMSRunes || !strings.HasSuffix(messages[0], "…") {
+		t.Fatalf("unexpected sms bodies: %q", messages)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(calls))
+	}
+	if !strings.HasPrefix(calls[0], `<Response><Say language="cmn-CN">任务 数据库&lt;主库&gt; 需要立即处理。`) {
+		t.Fatalf("unexpected twiml: %s", calls[0])
+	}
+
+	cfg.To = []string{"+15550000002"}
+	failures, err = SendMessage(context.Background(), cfg, msg)
+	if err == nil {
+		t.Fatal("expected error when every number fails")
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected failure detail when every number fails, got %v", failures)
+	}
+}
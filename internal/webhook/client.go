@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+// SendMessage renders the body template and calls the configured webhook.
+// GET requests carry no body; the fields are sent as query parameters instead.
+func SendMessage(ctx context.Context, cfg config.WebhookConfig, msg notify.Message) error {
+	data := newTemplateData(msg)
+
+	var (
+		req *http.Request
+		// signed 为参与签名的内容：GET 请求为编码后的查询串，其它方法为请求体。
+		signed []byte
+	)
+	if cfg.Method == http.MethodGet {
+		endpoint, err := url.Parse(cfg.URL)
+		if err != nil {
+			return fmt.Errorf("parse webhook url: %w", err)
+		}
+		query := endpoint.Query()
+		query.Set("taskName", data.TaskName)
+		query.Set("message", data.Message)
+		query.Set("timestamp", data.Timestamp)
+		query.Set("host", data.Host)
+		query.Set("level", data.Level)
+		endpoint.RawQuery = query.Encode()
+		signed = []byte(endpoint.RawQuery)
+
+		req, err = http.NewRequestWithContext(ctx, cfg.Method, endpoint.String(), nil)
+		if err != nil {
+			return fmt.Errorf("build webhook request: %w", err)
+		}
+	} else {
+		body, err := render(cfg, data)
+		if err != nil {
+			return err
+		}
+		signed = body
+
+		req, err = http.NewRequestWithContext(ctx, cfg.Method, cfg.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("build webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range cfg.Headers {
+		req.Header.Set(key, value)
+	}
+	if cfg.SignatureSecret != "" {
+		header := cfg.SignatureHeader
+		if header == "" {
+			header = config.DefaultWebhookSignatureHeader
+		}
+		req.Header.Set(header, sign(cfg.SignatureSecret, signed))
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !isSuccess(cfg.SuccessStatusCodes, resp.StatusCode) {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func newTemplateData(msg notify.Message) config.WebhookTemplateData {
+	host, _ := os.Hostname()
+	return config.WebhookTemplateData{
+		TaskName:  msg.TaskName,
+		Message:   msg.Body,
+		Timestamp: msg.Time.Format(time.RFC3339),
+		Time:      msg.FormattedTime(),
+		Host:      host,
+		Level:     string(msg.Level),
+		Text:      msg.Text(),
+	}
+}
+
+func render(cfg config.WebhookConfig, data config.WebhookTemplateData) ([]byte, error) {
+	tmpl, err := cfg.Template()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render webhook body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sign 返回 "sha256=" 前缀的十六进制 HMAC-SHA256 签名，便于接收方校验来源。
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func isSuccess(codes []int, status int) bool {
+	if len(codes) == 0 {
+		return status >= 200 && status < 300
+	}
+	for _, code := range codes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessageTemplateAndSignature(t *testing.T) {
+	t.Parallel()
+
+	var (
+		body      []byte
+		signature string
+		custom    string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get("X-Signature")
+		custom = r.Header.Get("X-Team")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	cfg := config.WebhookConfig{
+		URL:                srv.URL,
+		Method:             http.MethodPut,
+		Headers:            map[string]string{"X-Team": "infra"},
+		BodyTemplate:       `{"text":{{json (printf "[%s] %s" .Level .TaskName)}},"body":{{json .Message}}}`,
+		SuccessStatusCodes: []int{http.StatusAccepted},
+		SignatureSecret:    "s3cret",
+		SignatureHeader:    "X-Signature",
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+
+	msg := notify.Message{Time: time.Now(), TaskName: "发布", Body: "完成 \"v1\"", Level: notify.LevelSuccess}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("rendered body is not valid JSON: %v (%s)", err, body)
+	}
+	if got["text"] != "[success] 发布" || got["body"] != "完成 \"v1\"" {
+		t.Fatalf("unexpected rendered body: %v", got)
+	}
+	if custom != "infra" {
+		t.Fatalf("custom header not forwarded: %q", custom)
+	}
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != want {
+		t.Fatalf("unexpected signature %q, want %q", signature, want)
+	}
+
+	cfg.SuccessStatusCodes = []int{http.StatusOK}
+	if err := SendMessage(context.Background(), cfg, msg); err == nil {
+		t.Fatal("expected error when status code is not in success list")
+	}
+}
+
+func TestSendMessageGetUsesQuery(t *testing.T) {
+	t.Parallel()
+
+	var (
+		query     url.Values
+		rawQuery  string
+		body      []byte
+		signature string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		query = r.URL.Query()
+		rawQuery = r.URL.RawQuery
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(config.DefaultWebhookSignatureHeader)
+	}))
+	defer srv.Close()
+
+	cfg := config.WebhookConfig{URL: srv.URL + "/notify?token=abc", Method: http.MethodGet, SignatureSecret: "s3cret"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	msg := notify.Message{Time: time.Now(), TaskName: "发布 & 回滚", Body: "完成", Level: notify.LevelWarning}
+	if err := SendMessage(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if len(body) != 0 {
+		t.Fatalf("GET request must not carry a body, got %q", body)
+	}
+	if query.Get("token") != "abc" || query.Get("taskName") != "发布 & 回滚" || query.Get("message") != "完成" || query.Get("level") != "warning" {
+		t.Fatalf("unexpected query: %v", query)
+	}
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(rawQuery))
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != want {
+		t.Fatalf("unexpected signature %q, want %q", signature, want)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		cfg  config.WebhookConfig
+		want string
+	}{
+		{
+			name: "unknown field",
+			cfg:  config.WebhookConfig{URL: "https://hooks.example.com", Method: http.MethodPost, BodyTemplate: `{"task":{{json .Task}}}`},
+			want: "can't evaluate field Task",
+		},
+		{
+			name: "body template with GET",
+			cfg:  config.WebhookConfig{URL: "https://hooks.example.com", Method: http.MethodGet, BodyTemplate: `{"task":{{json .TaskName}}}`},
+			want: "not supported with GET",
+		},
+	}
+	for _, c := range cases {
+		if err := c.cfg.Validate(); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: expected error containing %q, got %v", c.name, c.want, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package wecom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+type textContent struct {
+	Content             string   `json:"content"`
+	MentionedList       []string `json:"mentioned_list,omitempty"`
+	MentionedMobileList []string `json:"mentioned_mobile_list,omitempty"`
+}
+
+type markdownContent struct {
+	Content string `json:"content"`
+}
+
+type payload struct {
+	MsgType  string           `json:"msgtype"`
+	Text     *textContent     `json:"text,omitempty"`
+	Markdown *markdownContent `json:"markdown,omitempty"`
+}
+
+type response struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// SendMessage posts a message to the configured WeCom group robot.
+func SendMessage(ctx context.Context, cfg config.WeComConfig, msg notify.Message) error {
+	base := strings.TrimRight(cfg.APIBaseURL, "/")
+	endpoint := fmt.Sprintf("%s/cgi-bin/webhook/send?key=%s", base, url.QueryEscape(cfg.Key))
+
+	body, err := json.Marshal(buildPayload(cfg, msg))
+	if err != nil {
+		return fmt.Errorf("encode wecom payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build wecom request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call wecom: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("wecom responded with %s", resp.Status)
+	}
+
+	// 企业微信在 HTTP 200 时通过 errcode 返回业务错误，例如 key 无效。
+	var result response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode wecom response: %w", err)
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("wecom responded with errcode %d: %s", result.ErrCode, result.ErrMsg)
+	}
+	return nil
+}
+
+func buildPayload(cfg config.WeComConfig, msg notify.Message) payload {
+	if cfg.MsgType == config.WeComMsgTypeText {
+		return payload{
+			MsgType: config.WeComMsgTypeText,
+			Text: &textContent{
+				Content:             msg.Text(),
+				MentionedList:       cfg.MentionedList,
+				MentionedMobileList: cfg.MentionedMobileList,
+			},
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**\n", msg.TaskName)
+	fmt.Fprintf(&b, "> 时间：<font color=\"comment\">%s</font>\n\n", msg.FormattedTime())
+	b.WriteString(msg.Body)
+	// markdown 消息不识别 mentioned_list，需要在正文中使用 <@userid> 提醒。
+	for _, userID := range cfg.MentionedList {
+		fmt.Fprintf(&b, "\n<@%s>", userID)
+	}
+
+	return payload{
+		MsgType:  config.WeComMsgTypeMarkdown,
+		Markdown: &markdownContent{Content: b.String()},
+	}
+}
@@ -0,0 +1,62 @@
+package wecom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zboyco/notify-mcp/internal/config"
+	"github.com/zboyco/notify-mcp/internal/notify"
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	var got []payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cgi-bin/webhook/send" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if key := r.URL.Query().Get("key"); key != "k+1" {
+			_, _ = w.Write([]byte(`{"errcode":93000,"errmsg":"invalid webhook url"}`))
+			return
+		}
+		var p payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		got = append(got, p)
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	msg := notify.Message{Time: time.Now(), TaskName: "构建", Body: "完成", Level: notify.LevelSuccess}
+	markdown := config.WeComConfig{APIBaseURL: srv.URL, Key: "k+1", MsgType: config.WeComMsgTypeMarkdown, MentionedList: []string{"zhangsan"}}
+	if err := SendMessage(context.Background(), markdown, msg); err != nil {
+		t.Fatalf("markdown SendMessage returned error: %v", err)
+	}
+	text := config.WeComConfig{APIBaseURL: srv.URL, Key: "k+1", MsgType: config.WeComMsgTypeText, MentionedMobileList: []string{"13800138000"}}
+	if err := SendMessage(context.Background(), text, msg); err != nil {
+		t.Fatalf("text SendMessage returned error: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(got))
+	}
+	if got[0].MsgType != "markdown" || got[0].Markdown == nil || !strings.Contains(got[0].Markdown.Content, "<@zhangsan>") {
+		t.Fatalf("unexpected markdown payload: %+v", got[0])
+	}
+	if got[1].MsgType != "text" || got[1].Text == nil || got[1].Text.MentionedMobileList[0] != "13800138000" {
+		t.Fatalf("unexpected text payload: %+v", got[1])
+	}
+
+	markdown.Key = "wrong"
+	err := SendMessage(context.Background(), markdown, msg)
+	if err == nil || !strings.Contains(err.Error(), "errcode 93000") {
+		t.Fatalf("expected errcode error, got %v", err)
+	}
+}